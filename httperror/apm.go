@@ -0,0 +1,47 @@
+package httperror
+
+import (
+	"net/http"
+	"sync"
+)
+
+var (
+	apmHookMu sync.RWMutex
+	apmHook   func(e *HTTPError, attrs map[string]any)
+)
+
+// SetAPMHook sets a function called synchronously whenever a 4xx or 5xx error
+// is created by one of the package constructors. attrs holds a copy of the
+// error's Meta plus the "status_code", "status_text" and "message" fields, and
+// "cause" when the error wraps another error.
+func SetAPMHook(fn func(e *HTTPError, attrs map[string]any)) {
+	apmHookMu.Lock()
+	defer apmHookMu.Unlock()
+	apmHook = fn
+}
+
+// ClearAPMHook removes the function set by SetAPMHook.
+func ClearAPMHook() {
+	SetAPMHook(nil)
+}
+
+// notifyAPM calls the APM hook, if any, for 4xx and 5xx errors.
+func notifyAPM(e *HTTPError) {
+	apmHookMu.RLock()
+	hook := apmHook
+	apmHookMu.RUnlock()
+	if hook == nil || e.Code < http.StatusBadRequest || e.Code > 599 {
+		return
+	}
+	attrs := make(map[string]any, len(e.Meta)+4)
+	for k, v := range e.Meta {
+		attrs[k] = v
+	}
+	attrs["status_code"] = e.Code
+	attrs["status_text"] = http.StatusText(e.Code)
+	attrs["message"] = e.Message
+	if e.err != nil {
+		attrs["cause"] = e.err.Error()
+	}
+	hook(e, attrs)
+}
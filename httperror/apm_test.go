@@ -0,0 +1,132 @@
+package httperror
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAPMHook(t *testing.T) {
+	t.Run("called for client and server errors", func(t *testing.T) {
+		var got []*HTTPError
+		SetAPMHook(func(e *HTTPError, attrs map[string]any) {
+			got = append(got, e)
+		})
+		defer ClearAPMHook()
+
+		notFound := NewHTTPError(http.StatusNotFound, "not found")
+		wrapped := WrapError(http.StatusBadGateway, errors.New("upstream"))
+		assert.Equal(t, []*HTTPError{notFound, wrapped}, got)
+	})
+
+	t.Run("not called for non-error codes", func(t *testing.T) {
+		called := false
+		SetAPMHook(func(e *HTTPError, attrs map[string]any) {
+			called = true
+		})
+		defer ClearAPMHook()
+
+		NewHTTPError(http.StatusOK, "ok")
+		NewHTTPError(http.StatusFound, "found")
+		assert.False(t, called)
+	})
+
+	t.Run("builds attrs from meta and standard fields", func(t *testing.T) {
+		var attrs map[string]any
+		SetAPMHook(func(e *HTTPError, a map[string]any) {
+			attrs = a
+		})
+		defer ClearAPMHook()
+		SetDefaultEnvironment("production")
+		defer SetDefaultEnvironment("")
+
+		WrapErrorf(http.StatusServiceUnavailable, errors.New("db down"), "unavailable")
+		assert.Equal(t, map[string]any{
+			EnvironmentMetaKey: "production",
+			"status_code":      http.StatusServiceUnavailable,
+			"status_text":      "Service Unavailable",
+			"message":          "unavailable",
+			"cause":            "db down",
+		}, attrs)
+	})
+
+	t.Run("called after options are applied", func(t *testing.T) {
+		var attrs map[string]any
+		SetAPMHook(func(e *HTTPError, a map[string]any) {
+			attrs = a
+		})
+		defer ClearAPMHook()
+
+		NewWithOptions(WithCode(http.StatusConflict), WithMetaValue("id", "1"))
+		assert.Equal(t, http.StatusConflict, attrs["status_code"])
+		assert.Equal(t, "1", attrs["id"])
+	})
+
+	t.Run("sees the final message and meta of constructors", func(t *testing.T) {
+		var attrs map[string]any
+		SetAPMHook(func(e *HTTPError, a map[string]any) {
+			attrs = a
+		})
+		defer ClearAPMHook()
+
+		resp := &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{"X-Upstream": {"users"}},
+			Body:       io.NopCloser(strings.NewReader(`{"code":404,"message":"user not found","meta":{"id":"1"}}`)),
+		}
+		_, err := NewFromResponse(resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "user not found", attrs["message"])
+		assert.Equal(t, "1", attrs["id"])
+		assert.Contains(t, attrs, ResponseHeadersMetaKey)
+
+		NewMethodNotAllowedWithMethods(http.MethodGet)
+		assert.Equal(t, []string{http.MethodGet}, attrs[AllowedMethodsMetaKey])
+
+		NewBatchValidationError(map[string]string{"email": "invalid email"})
+		assert.Equal(t, map[string]string{"email": "invalid email"}, attrs[ValidationErrorsMetaKey])
+
+		NewPreconditionFailed(`"v2"`)
+		assert.Equal(t, `"v2"`, attrs[ETagMetaKey])
+
+		NewProxyAuthenticationRequired("Basic", `realm="proxy"`)
+		assert.Equal(t, `Basic realm="proxy"`, attrs[ProxyAuthenticateMetaKey])
+
+		UpgradeStatus(NewHTTPError(http.StatusServiceUnavailable, "unavailable"), http.StatusInternalServerError)
+		assert.Equal(t, http.StatusServiceUnavailable, attrs[OriginalCodeMetaKey])
+
+		Annotate(NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "2"), "loading user")
+		assert.Equal(t, "loading user: not found", attrs["message"])
+		assert.Equal(t, "2", attrs["id"])
+	})
+
+	t.Run("sees the truncated message", func(t *testing.T) {
+		var attrs map[string]any
+		SetAPMHook(func(e *HTTPError, a map[string]any) {
+			attrs = a
+		})
+		defer ClearAPMHook()
+		SetDefaultMaxMessageLength(4)
+		defer SetDefaultMaxMessageLength(0)
+
+		NewHTTPError(http.StatusNotFound, "user not found")
+		assert.Equal(t, "user...", attrs["message"])
+	})
+}
+
+func TestClearAPMHook(t *testing.T) {
+	t.Run("stops calling the hook", func(t *testing.T) {
+		called := false
+		SetAPMHook(func(e *HTTPError, attrs map[string]any) {
+			called = true
+		})
+		ClearAPMHook()
+
+		NewHTTPError(http.StatusInternalServerError, "internal")
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,58 @@
+package httperror
+
+import "net/http"
+
+// ProxyAuthenticateMetaKey is the Meta key used to store the proxy authentication challenge.
+const ProxyAuthenticateMetaKey = "proxy_authenticate"
+
+// challenge formats an RFC 7235 authentication challenge such as `Bearer realm="api"`.
+func challenge(scheme, params string) string {
+	if params == "" {
+		return scheme
+	}
+	return scheme + " " + params
+}
+
+// WithAuthenticateChallenge sets the WWW-Authenticate response header to the
+// challenge formed by scheme and params, e.g. `Bearer realm="api"`.
+func (e *HTTPError) WithAuthenticateChallenge(scheme, params string) *HTTPError {
+	e.setHeader("WWW-Authenticate", challenge(scheme, params))
+	return e
+}
+
+// WithAuthenticateChallenge sets the WWW-Authenticate response header of the HTTPError.
+func WithAuthenticateChallenge(scheme, params string) Option {
+	return func(e *HTTPError) {
+		e.WithAuthenticateChallenge(scheme, params)
+	}
+}
+
+// GetAuthenticateChallenge returns the WWW-Authenticate header of the HTTPError in err's chain.
+func GetAuthenticateChallenge(err error) string {
+	httpErr, ok := AsHTTPError(err)
+	if !ok {
+		return ""
+	}
+	return httpErr.Header.Get("WWW-Authenticate")
+}
+
+// NewUnauthorized creates a 401 HTTPError with the given message and applies opts,
+// such as WithAuthenticateChallenge.
+func NewUnauthorized(message string, opts ...Option) *HTTPError {
+	return newHTTPError(http.StatusUnauthorized, message, nil, opts...)
+}
+
+// WithProxyAuthenticate records the proxy authentication challenge formed by scheme
+// and params in Meta and in the Proxy-Authenticate response header.
+func (e *HTTPError) WithProxyAuthenticate(scheme, params string) *HTTPError {
+	value := challenge(scheme, params)
+	e.setHeader("Proxy-Authenticate", value)
+	return e.AddMetaValue(ProxyAuthenticateMetaKey, value)
+}
+
+// NewProxyAuthenticationRequired creates a 407 HTTPError with the given proxy authentication challenge.
+func NewProxyAuthenticationRequired(scheme, params string) *HTTPError {
+	return newHTTPError(http.StatusProxyAuthRequired, http.StatusText(http.StatusProxyAuthRequired), nil, func(e *HTTPError) {
+		e.WithProxyAuthenticate(scheme, params)
+	})
+}
@@ -0,0 +1,95 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorWithAuthenticateChallenge(t *testing.T) {
+	t.Run("sets WWW-Authenticate header", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnauthorized, "unauthorized").WithAuthenticateChallenge("Bearer", `realm="api"`)
+		assert.Equal(t, `Bearer realm="api"`, err.Header.Get("WWW-Authenticate"))
+	})
+
+	t.Run("omits empty params", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnauthorized, "unauthorized").WithAuthenticateChallenge("Negotiate", "")
+		assert.Equal(t, "Negotiate", err.Header.Get("WWW-Authenticate"))
+	})
+
+	t.Run("is written as response header", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewHTTPError(http.StatusUnauthorized, "unauthorized").WithAuthenticateChallenge("Basic", `realm="admin"`).WriteResponse(rec)
+		assert.Equal(t, `Basic realm="admin"`, rec.Header().Get("WWW-Authenticate"))
+	})
+}
+
+func TestGetAuthenticateChallenge(t *testing.T) {
+	t.Run("returns challenge from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnauthorized, "unauthorized").WithAuthenticateChallenge("Bearer", `realm="api"`)
+		assert.Equal(t, `Bearer realm="api"`, GetAuthenticateChallenge(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", GetAuthenticateChallenge(NewHTTPError(http.StatusUnauthorized, "unauthorized")))
+		assert.Equal(t, "", GetAuthenticateChallenge(errors.New("standard error")))
+		assert.Equal(t, "", GetAuthenticateChallenge(nil))
+	})
+}
+
+func TestNewUnauthorized(t *testing.T) {
+	t.Run("creates 401 without challenge", func(t *testing.T) {
+		err := NewUnauthorized("token expired")
+		assert.Equal(t, http.StatusUnauthorized, err.Code)
+		assert.Equal(t, "token expired", err.Message)
+		assert.Equal(t, "", GetAuthenticateChallenge(err))
+	})
+
+	t.Run("applies challenge option", func(t *testing.T) {
+		err := NewUnauthorized("token expired", WithAuthenticateChallenge("Bearer", `error="invalid_token"`))
+		assert.Equal(t, `Bearer error="invalid_token"`, GetAuthenticateChallenge(err))
+	})
+}
+
+func TestHTTPErrorWithProxyAuthenticate(t *testing.T) {
+	t.Run("stores challenge in meta and header", func(t *testing.T) {
+		err := NewHTTPError(http.StatusProxyAuthRequired, "proxy auth required").WithProxyAuthenticate("Basic", `realm="proxy"`)
+		assert.Equal(t, `Basic realm="proxy"`, err.Meta[ProxyAuthenticateMetaKey])
+		assert.Equal(t, `Basic realm="proxy"`, err.Header.Get("Proxy-Authenticate"))
+	})
+}
+
+func TestNewProxyAuthenticationRequired(t *testing.T) {
+	t.Run("creates 407 with challenge", func(t *testing.T) {
+		err := NewProxyAuthenticationRequired("Basic", `realm="proxy"`)
+		assert.Equal(t, http.StatusProxyAuthRequired, err.Code)
+		assert.Equal(t, "Proxy Authentication Required", err.Message)
+		assert.Equal(t, `Basic realm="proxy"`, err.Header.Get("Proxy-Authenticate"))
+	})
+
+	t.Run("is written as response header", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, NewProxyAuthenticationRequired("Basic", `realm="proxy"`))
+		assert.Equal(t, http.StatusProxyAuthRequired, rec.Code)
+		assert.Equal(t, `Basic realm="proxy"`, rec.Header().Get("Proxy-Authenticate"))
+	})
+
+	t.Run("header is derived from meta for 407 errors", func(t *testing.T) {
+		err, decodeErr := NewHTTPErrorFromJSON([]byte(`{"code":407,"message":"proxy auth required","meta":{"proxy_authenticate":"Basic"}}`))
+		assert.NoError(t, decodeErr)
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, err)
+		assert.Equal(t, "Basic", rec.Header().Get("Proxy-Authenticate"))
+	})
+
+	t.Run("header is not derived for other codes", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnauthorized, "unauthorized").AddMetaValue(ProxyAuthenticateMetaKey, "Basic")
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, err)
+		assert.Empty(t, rec.Header().Get("Proxy-Authenticate"))
+	})
+}
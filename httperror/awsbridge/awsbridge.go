@@ -0,0 +1,79 @@
+// Package awsbridge converts AWS SDK for Go v2 errors into HTTPErrors.
+//
+// The package matches the SDK's error interfaces structurally, so it does not
+// depend on the AWS SDK or smithy-go modules.
+package awsbridge
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Gobusters/ectoerror/httperror"
+)
+
+// ErrorCodeMetaKey is the Meta key used to store the AWS error code.
+const ErrorCodeMetaKey = "aws_error_code"
+
+// httpResponseError matches smithy-go's HTTP response errors, which expose the response status.
+type httpResponseError interface {
+	HTTPStatusCode() int
+}
+
+// apiError matches smithy.APIError.
+type apiError interface {
+	ErrorCode() string
+	ErrorMessage() string
+}
+
+// errorCodes maps well-known AWS error codes to HTTP status codes.
+var errorCodes = map[string]int{
+	"AccessDeniedException":           http.StatusForbidden,
+	"AccessDenied":                    http.StatusForbidden,
+	"UnrecognizedClientException":     http.StatusUnauthorized,
+	"ExpiredTokenException":           http.StatusUnauthorized,
+	"ValidationException":             http.StatusBadRequest,
+	"ResourceNotFoundException":       http.StatusNotFound,
+	"NoSuchKey":                       http.StatusNotFound,
+	"NoSuchBucket":                    http.StatusNotFound,
+	"ResourceInUseException":          http.StatusConflict,
+	"ConditionalCheckFailedException": http.StatusConflict,
+	"ThrottlingException":             http.StatusTooManyRequests,
+	"TooManyRequestsException":        http.StatusTooManyRequests,
+	"RequestTimeout":                  http.StatusRequestTimeout,
+	"InternalServerError":             http.StatusInternalServerError,
+	"ServiceUnavailable":              http.StatusServiceUnavailable,
+}
+
+// FromAWSError converts an AWS SDK error to an HTTPError.
+// The status code is taken from the HTTP response when available, otherwise
+// it is derived from the AWS error code, falling back to 500.
+func FromAWSError(err error) *httperror.HTTPError {
+	if err == nil {
+		return nil
+	}
+
+	code := 0
+	var respErr httpResponseError
+	if errors.As(err, &respErr) {
+		code = respErr.HTTPStatusCode()
+	}
+
+	var apiErr apiError
+	hasAPIErr := errors.As(err, &apiErr)
+	if code == 0 && hasAPIErr {
+		code = errorCodes[apiErr.ErrorCode()]
+	}
+	if code == 0 {
+		code = http.StatusInternalServerError
+	}
+
+	message := err.Error()
+	if hasAPIErr && apiErr.ErrorMessage() != "" {
+		message = apiErr.ErrorMessage()
+	}
+	httpErr := httperror.NewWithCause(code, message, err)
+	if hasAPIErr {
+		httpErr.AddMetaValue(ErrorCodeMetaKey, apiErr.ErrorCode())
+	}
+	return httpErr
+}
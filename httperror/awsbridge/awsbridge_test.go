@@ -0,0 +1,80 @@
+package awsbridge
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Gobusters/ectoerror/httperror"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAPIError struct {
+	code    string
+	message string
+}
+
+func (e *fakeAPIError) Error() string        { return fmt.Sprintf("api error %s: %s", e.code, e.message) }
+func (e *fakeAPIError) ErrorCode() string    { return e.code }
+func (e *fakeAPIError) ErrorMessage() string { return e.message }
+
+type fakeResponseError struct {
+	status int
+	err    error
+}
+
+func (e *fakeResponseError) Error() string       { return fmt.Sprintf("http %d: %v", e.status, e.err) }
+func (e *fakeResponseError) HTTPStatusCode() int { return e.status }
+func (e *fakeResponseError) Unwrap() error       { return e.err }
+
+func TestFromAWSError(t *testing.T) {
+	t.Run("uses HTTP response status code", func(t *testing.T) {
+		err := &fakeResponseError{status: http.StatusConflict, err: &fakeAPIError{code: "AccessDeniedException", message: "denied"}}
+		httpErr := FromAWSError(err)
+		assert.Equal(t, http.StatusConflict, httpErr.Code)
+		assert.Equal(t, "denied", httpErr.Message)
+		assert.Equal(t, "AccessDeniedException", httpErr.Meta[ErrorCodeMetaKey])
+	})
+
+	t.Run("maps AWS error codes", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, FromAWSError(&fakeAPIError{code: "AccessDeniedException"}).Code)
+		assert.Equal(t, http.StatusNotFound, FromAWSError(&fakeAPIError{code: "ResourceNotFoundException"}).Code)
+		assert.Equal(t, http.StatusTooManyRequests, FromAWSError(&fakeAPIError{code: "ThrottlingException"}).Code)
+	})
+
+	t.Run("falls back to 500 for unknown error codes", func(t *testing.T) {
+		httpErr := FromAWSError(&fakeAPIError{code: "SomethingOdd", message: "odd"})
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+	})
+
+	t.Run("wraps non-AWS errors as 500", func(t *testing.T) {
+		stdErr := errors.New("network unreachable")
+		httpErr := FromAWSError(stdErr)
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		assert.Equal(t, "network unreachable", httpErr.Message)
+		assert.True(t, errors.Is(httpErr, stdErr))
+	})
+
+	t.Run("keeps original error in chain", func(t *testing.T) {
+		apiErr := &fakeAPIError{code: "ResourceNotFoundException", message: "table not found"}
+		httpErr := FromAWSError(fmt.Errorf("get item: %w", apiErr))
+		var target *fakeAPIError
+		assert.True(t, errors.As(httpErr, &target))
+	})
+
+	t.Run("does not modify an HTTPError input", func(t *testing.T) {
+		original := httperror.NewWithCause(http.StatusBadGateway, "bad gateway", &fakeAPIError{code: "ThrottlingException", message: "slow down"})
+		httpErr := FromAWSError(original)
+		assert.NotSame(t, original, httpErr)
+		assert.Equal(t, "slow down", httpErr.Message)
+		assert.Equal(t, "ThrottlingException", httpErr.Meta[ErrorCodeMetaKey])
+		assert.True(t, errors.Is(httpErr, original))
+		assert.Equal(t, "bad gateway", original.Message)
+		assert.NotContains(t, original.Meta, ErrorCodeMetaKey)
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, FromAWSError(nil))
+	})
+}
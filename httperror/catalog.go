@@ -0,0 +1,103 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrCatalogFrozen is reported by ErrorCatalog.Validate for entries defined after Freeze.
+var ErrCatalogFrozen = errors.New("httperror: catalog is frozen")
+
+// ErrorCatalog holds the named errors of an API so they can be defined and
+// validated together at startup.
+type ErrorCatalog struct {
+	mu      sync.RWMutex
+	names   []string
+	entries map[string]*HTTPError
+	frozen  bool
+	errs    []error
+}
+
+// NewErrorCatalog creates a new, empty ErrorCatalog.
+func NewErrorCatalog() *ErrorCatalog {
+	return &ErrorCatalog{entries: make(map[string]*HTTPError)}
+}
+
+// Define adds e to the catalog under name, replacing any error already defined
+// under that name. Definitions after Freeze are ignored and reported by Validate.
+func (c *ErrorCatalog) Define(name string, e *HTTPError) *ErrorCatalog {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.frozen {
+		c.errs = append(c.errs, fmt.Errorf("define %q: %w", name, ErrCatalogFrozen))
+		return c
+	}
+	if _, ok := c.entries[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.entries[name] = e
+	return c
+}
+
+// Lookup returns the error defined under name. Catalog errors are shared
+// values and must not be modified; use New to create an error from them.
+func (c *ErrorCatalog) Lookup(name string) (*HTTPError, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.entries[name]
+	return e, ok
+}
+
+// Validate checks that every entry has a non-empty name and a status code in
+// the range [100, 599], and reports definitions attempted after Freeze.
+// It returns an error listing all violations, or nil if the catalog is valid.
+func (c *ErrorCatalog) Validate() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	violations := append([]error(nil), c.errs...)
+	for _, name := range c.names {
+		e := c.entries[name]
+		switch {
+		case name == "":
+			violations = append(violations, errors.New("entry has an empty name"))
+		case e == nil:
+			violations = append(violations, fmt.Errorf("%q: nil HTTPError", name))
+		case e.Code < 100 || e.Code > 599:
+			violations = append(violations, fmt.Errorf("%q: status code %d is out of range [100, 599]", name, e.Code))
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid ErrorCatalog: %w", errors.Join(violations...))
+}
+
+// MustValidate is like Validate but panics if the catalog is invalid.
+func (c *ErrorCatalog) MustValidate() {
+	if err := c.Validate(); err != nil {
+		panic(err)
+	}
+}
+
+// New returns a copy of the error defined under name, with its message replaced
+// by overrideMessage if it is non-empty. It returns nil if name is not defined.
+func (c *ErrorCatalog) New(name string, overrideMessage string) *HTTPError {
+	e, ok := c.Lookup(name)
+	if !ok || e == nil {
+		return nil
+	}
+	clone := e.Clone()
+	if overrideMessage != "" {
+		clone.Message = overrideMessage
+	}
+	return clone
+}
+
+// Freeze makes the catalog immutable. Later calls to Define are reported by Validate.
+func (c *ErrorCatalog) Freeze() *ErrorCatalog {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frozen = true
+	return c
+}
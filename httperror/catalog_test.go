@@ -0,0 +1,84 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCatalog(t *testing.T) {
+	t.Run("defines and looks up errors", func(t *testing.T) {
+		notFound := NewHTTPError(http.StatusNotFound, "user not found")
+		catalog := NewErrorCatalog().Define("user_not_found", notFound)
+		e, ok := catalog.Lookup("user_not_found")
+		assert.True(t, ok)
+		assert.Same(t, notFound, e)
+		_, ok = catalog.Lookup("missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("redefining replaces entry", func(t *testing.T) {
+		replacement := NewHTTPError(http.StatusGone, "user deleted")
+		catalog := NewErrorCatalog().
+			Define("user", NewHTTPError(http.StatusNotFound, "user not found")).
+			Define("user", replacement)
+		e, _ := catalog.Lookup("user")
+		assert.Same(t, replacement, e)
+		assert.NoError(t, catalog.Validate())
+	})
+
+	t.Run("new returns copy with override message", func(t *testing.T) {
+		catalog := NewErrorCatalog().Define("invalid", NewHTTPError(http.StatusBadRequest, "invalid input").AddMetaValue("field", "email"))
+		e := catalog.New("invalid", "email is required")
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.Equal(t, "email is required", e.Message)
+		assert.Equal(t, "email", e.Meta["field"])
+
+		e.AddMetaValue("field", "name")
+		original, _ := catalog.Lookup("invalid")
+		assert.Equal(t, "email", original.Meta["field"])
+		assert.Equal(t, "invalid input", catalog.New("invalid", "").Message)
+		assert.Nil(t, catalog.New("missing", "message"))
+	})
+}
+
+func TestErrorCatalogValidate(t *testing.T) {
+	t.Run("valid catalog", func(t *testing.T) {
+		catalog := NewErrorCatalog().
+			Define("not_found", NewHTTPError(http.StatusNotFound, "not found")).
+			Define("conflict", NewHTTPError(http.StatusConflict, "conflict"))
+		assert.NoError(t, catalog.Validate())
+		assert.NotPanics(t, catalog.MustValidate)
+	})
+
+	t.Run("reports all violations", func(t *testing.T) {
+		catalog := NewErrorCatalog().
+			Define("", NewHTTPError(http.StatusNotFound, "not found")).
+			Define("bad_code", NewHTTPError(600, "bad code")).
+			Define("nil", nil)
+		err := catalog.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "entry has an empty name")
+		assert.Contains(t, err.Error(), `"bad_code": status code 600 is out of range [100, 599]`)
+		assert.Contains(t, err.Error(), `"nil": nil HTTPError`)
+		assert.Panics(t, catalog.MustValidate)
+	})
+}
+
+func TestErrorCatalogFreeze(t *testing.T) {
+	t.Run("rejects definitions after freeze", func(t *testing.T) {
+		catalog := NewErrorCatalog().
+			Define("not_found", NewHTTPError(http.StatusNotFound, "not found")).
+			Freeze()
+		assert.NoError(t, catalog.Validate())
+
+		catalog.Define("conflict", NewHTTPError(http.StatusConflict, "conflict"))
+		_, ok := catalog.Lookup("conflict")
+		assert.False(t, ok)
+		err := catalog.Validate()
+		assert.True(t, errors.Is(err, ErrCatalogFrozen))
+		assert.Contains(t, err.Error(), `define "conflict"`)
+	})
+}
@@ -0,0 +1,132 @@
+package httperror
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// MiddlewareChain composes the package's error-handling middleware into a
+// single middleware. Create one with NewMiddlewareChain.
+type MiddlewareChain struct {
+	recovery   bool
+	enrichment bool
+	logging    bool
+	logger     *slog.Logger
+	record     func(code int)
+}
+
+// NewMiddlewareChain returns an empty MiddlewareChain.
+func NewMiddlewareChain() *MiddlewareChain {
+	return &MiddlewareChain{}
+}
+
+// WithRecovery converts panics in the handler into 500 errors.
+// A panic with an *HTTPError value keeps its status code.
+func (c *MiddlewareChain) WithRecovery() *MiddlewareChain {
+	c.recovery = true
+	return c
+}
+
+// WithEnrichment records the request method, URL path and the X-Request-ID
+// and X-Correlation-ID headers on the error. Values already set are kept.
+func (c *MiddlewareChain) WithEnrichment() *MiddlewareChain {
+	c.enrichment = true
+	return c
+}
+
+// WithLogging logs each error to logger, at error level for 5xx and warn level
+// otherwise. A nil logger uses slog.Default.
+func (c *MiddlewareChain) WithLogging(logger *slog.Logger) *MiddlewareChain {
+	c.logging = true
+	c.logger = logger
+	return c
+}
+
+// WithMetrics calls record with the status code of each error, so it can be
+// counted by the caller's metrics system. A nil record disables metrics.
+func (c *MiddlewareChain) WithMetrics(record func(code int)) *MiddlewareChain {
+	c.record = record
+	return c
+}
+
+// Build returns a middleware that serves next and handles the error it
+// produces: the error returned when next is an HTTPHandlerFunc, or the panic
+// recovered when WithRecovery is set. The error is enriched, logged and counted,
+// in that order, before being written with the configured ErrorFormatter.
+func (c *MiddlewareChain) Build() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			httpErr := c.serve(next, w, r)
+			if httpErr == nil {
+				return
+			}
+			if c.enrichment {
+				httpErr = enrichFromRequest(httpErr, r)
+			}
+			if c.logging {
+				logHTTPError(c.logger, r, httpErr)
+			}
+			if c.record != nil {
+				c.record(httpErr.Code)
+			}
+			writeFormatted(w, httpErr)
+		})
+	}
+}
+
+// serve calls next and returns the error it produced, if any.
+func (c *MiddlewareChain) serve(next http.Handler, w http.ResponseWriter, r *http.Request) (httpErr *HTTPError) {
+	if c.recovery {
+		defer func() {
+			if v := recover(); v != nil {
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				httpErr = NewFromPanic(v)
+			}
+		}()
+	}
+	fn, ok := next.(HTTPHandlerFunc)
+	if !ok {
+		next.ServeHTTP(w, r)
+		return nil
+	}
+	err := fn(w, r)
+	if err == nil {
+		return nil
+	}
+	if !errors.As(err, &httpErr) {
+		httpErr = WrapError(GetDefaultCode(), err)
+	}
+	return httpErr
+}
+
+// enrichFromRequest returns a clone of e recording details of r.
+// The clone keeps shared errors such as the sentinels unmodified.
+func enrichFromRequest(e *HTTPError, r *http.Request) *HTTPError {
+	e = e.Clone()
+	setIfMissing := func(key, value string) {
+		if _, ok := e.Meta[key]; !ok && value != "" {
+			e.Meta[key] = value
+		}
+	}
+	setIfMissing(RequestMethodMetaKey, r.Method)
+	setIfMissing(RequestPathMetaKey, r.URL.Path)
+	setIfMissing(RequestIDMetaKey, r.Header.Get("X-Request-ID"))
+	setIfMissing(CorrelationIDMetaKey, r.Header.Get("X-Correlation-ID"))
+	return e
+}
+
+// logHTTPError logs e for the request r.
+func logHTTPError(logger *slog.Logger, r *http.Request, e *HTTPError) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	level := slog.LevelWarn
+	if e.Code >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	logger.Log(r.Context(), level, "http error",
+		"method", r.Method, "path", r.URL.Path, "status", e.Code, "message", e.Message)
+}
@@ -0,0 +1,163 @@
+package httperror
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveChain(chain *MiddlewareChain, h http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	chain.Build()(h).ServeHTTP(rec, r)
+	return rec
+}
+
+func TestMiddlewareChain(t *testing.T) {
+	t.Run("writes returned errors without options", func(t *testing.T) {
+		h := HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return NewHTTPError(http.StatusNotFound, "not found")
+		})
+		rec := serveChain(NewMiddlewareChain(), h, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.JSONEq(t, `{"code":404,"message":"not found","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("serves plain handlers unchanged", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		})
+		rec := serveChain(NewMiddlewareChain().WithEnrichment(), h, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+	})
+
+	t.Run("methods return the chain", func(t *testing.T) {
+		c := NewMiddlewareChain()
+		assert.Same(t, c, c.WithRecovery().WithEnrichment().WithLogging(nil).WithMetrics(nil))
+	})
+}
+
+func TestMiddlewareChainWithRecovery(t *testing.T) {
+	t.Run("converts panics to 500", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		})
+		rec := serveChain(NewMiddlewareChain().WithRecovery(), h, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"code":500,"message":"boom","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("keeps HTTPError panic code", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(NewHTTPError(http.StatusConflict, "conflict"))
+		})
+		rec := serveChain(NewMiddlewareChain().WithRecovery(), h, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("agrees with HTTPErrorMiddleware on wrapped HTTPError panics", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(fmt.Errorf("loading user: %w", NewHTTPError(http.StatusNotFound, "not found")))
+		})
+		rec := serveChain(NewMiddlewareChain().WithRecovery(), h, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		middlewareRec := httptest.NewRecorder()
+		HTTPErrorMiddleware(h).ServeHTTP(middlewareRec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, middlewareRec.Code, rec.Code)
+		assert.Equal(t, middlewareRec.Body.String(), rec.Body.String())
+	})
+
+	t.Run("re-panics ErrAbortHandler", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		})
+		assert.Panics(t, func() {
+			serveChain(NewMiddlewareChain().WithRecovery(), h, httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
+
+func TestMiddlewareChainWithEnrichment(t *testing.T) {
+	t.Run("records request details", func(t *testing.T) {
+		h := HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return ErrNotFound
+		})
+		r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+		r.Header.Set("X-Request-ID", "req-1")
+		rec := serveChain(NewMiddlewareChain().WithEnrichment(), h, r)
+		assert.JSONEq(t, `{"code":404,"message":"","meta":{"request_method":"GET","request_path":"/users/1","_request_id":"req-1"},"schema_version":"1"}`, rec.Body.String())
+		assert.Empty(t, ErrNotFound.Meta)
+	})
+
+	t.Run("keeps existing values", func(t *testing.T) {
+		h := HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return NewHTTPError(http.StatusBadRequest, "bad request").WithRequestID("original")
+		})
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set("X-Request-ID", "req-1")
+		rec := serveChain(NewMiddlewareChain().WithEnrichment(), h, r)
+		assert.Contains(t, rec.Body.String(), `"_request_id":"original"`)
+	})
+}
+
+func TestMiddlewareChainWithLogging(t *testing.T) {
+	t.Run("logs errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+		h := HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("boom")
+		})
+		serveChain(NewMiddlewareChain().WithLogging(logger), h, httptest.NewRequest(http.MethodGet, "/items", nil))
+		assert.Contains(t, buf.String(), "level=ERROR")
+		assert.Contains(t, buf.String(), "path=/items")
+		assert.Contains(t, buf.String(), "status=500")
+	})
+
+	t.Run("logs client errors as warnings", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+		h := HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return NewHTTPError(http.StatusBadRequest, "bad request")
+		})
+		serveChain(NewMiddlewareChain().WithLogging(logger), h, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Contains(t, buf.String(), "level=WARN")
+	})
+}
+
+func TestMiddlewareChainWithMetrics(t *testing.T) {
+	t.Run("records errors by status code", func(t *testing.T) {
+		var codes []int
+		h := HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return NewHTTPError(http.StatusTeapot, "teapot")
+		})
+		chain := NewMiddlewareChain().WithMetrics(func(code int) {
+			codes = append(codes, code)
+		})
+		serveChain(chain, h, httptest.NewRequest(http.MethodGet, "/", nil))
+		serveChain(chain, h, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, []int{http.StatusTeapot, http.StatusTeapot}, codes)
+	})
+
+	t.Run("does not record successful requests", func(t *testing.T) {
+		called := false
+		h := HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return nil
+		})
+		chain := NewMiddlewareChain().WithMetrics(func(code int) {
+			called = true
+		})
+		serveChain(chain, h, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.False(t, called)
+	})
+
+	t.Run("does not register expvar handler", func(t *testing.T) {
+		_, pattern := http.DefaultServeMux.Handler(httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+		assert.Empty(t, pattern)
+	})
+}
@@ -0,0 +1,89 @@
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ResponseHeadersMetaKey is the reserved Meta key used to store downstream response headers.
+const ResponseHeadersMetaKey = "_response_headers"
+
+// NewFromResponse creates an HTTPError from a downstream HTTP response.
+// The body is read and closed. If it holds a JSON-encoded HTTPError, its message
+// and meta are used; otherwise the message is http.StatusText of the status code.
+// The code always comes from resp.StatusCode, and the response headers are
+// stored in Meta under "_response_headers".
+func NewFromResponse(resp *http.Response) (*HTTPError, error) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded HTTPError
+	if len(body) > 0 && json.Unmarshal(body, &decoded) != nil {
+		decoded = HTTPError{}
+	}
+	message := decoded.Message
+	if message == "" {
+		message, _ = registeredCodeText(resp.StatusCode)
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	return newHTTPError(resp.StatusCode, message, nil, func(e *HTTPError) {
+		for k, v := range decoded.Meta {
+			e.Meta[k] = v
+		}
+		e.WithResponseHeaders(resp.Header)
+	}), nil
+}
+
+// CheckResponse returns nil for 2xx responses and otherwise behaves like NewFromResponse.
+// The body of a 2xx response is left unread for the caller.
+func CheckResponse(resp *http.Response) (*HTTPError, error) {
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil, nil
+	}
+	return NewFromResponse(resp)
+}
+
+// WithResponseHeaders stores a copy of the downstream response headers h in Meta
+// under "_response_headers". They are forwarded when the error is written as a
+// response, except for credential and hop-by-hop headers such as Set-Cookie,
+// and are left out of the encoded body. A nil h is ignored.
+func (e *HTTPError) WithResponseHeaders(h http.Header) *HTTPError {
+	if h == nil {
+		return e
+	}
+	return e.AddMetaValue(ResponseHeadersMetaKey, h.Clone())
+}
+
+// encodableMeta returns a copy of meta without the downstream response headers,
+// which are only forwarded as headers and never encoded into a body.
+func encodableMeta(meta map[string]any) map[string]any {
+	encoded := make(map[string]any, len(meta))
+	for k, v := range meta {
+		if k != ResponseHeadersMetaKey {
+			encoded[k] = v
+		}
+	}
+	return encoded
+}
+
+// ResponseHeaders returns the downstream response headers stored on the HTTPError in err's chain.
+func ResponseHeaders(err error) http.Header {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return nil
+	}
+	switch v := httpErr.Meta[ResponseHeadersMetaKey].(type) {
+	case http.Header:
+		return v
+	case map[string][]string:
+		return v
+	}
+	return nil
+}
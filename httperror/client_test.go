@@ -0,0 +1,173 @@
+package httperror
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type trackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func newTestResponse(code int, body string) (*http.Response, *trackingBody) {
+	tb := &trackingBody{Reader: strings.NewReader(body)}
+	header := make(http.Header)
+	header.Set("Retry-After", "30")
+	return &http.Response{StatusCode: code, Header: header, Body: tb}, tb
+}
+
+func TestNewFromResponse(t *testing.T) {
+	t.Run("decodes JSON error body", func(t *testing.T) {
+		resp, body := newTestResponse(http.StatusNotFound, `{"code":400,"message":"user not found","meta":{"user_id":"123"}}`)
+		err, readErr := NewFromResponse(resp)
+		assert.NoError(t, readErr)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		assert.Equal(t, "user not found", err.Message)
+		assert.Equal(t, "123", err.Meta["user_id"])
+		assert.True(t, body.closed)
+	})
+
+	t.Run("falls back to status text for plain text body", func(t *testing.T) {
+		resp, body := newTestResponse(http.StatusBadGateway, "upstream exploded")
+		err, readErr := NewFromResponse(resp)
+		assert.NoError(t, readErr)
+		assert.Equal(t, http.StatusBadGateway, err.Code)
+		assert.Equal(t, "Bad Gateway", err.Message)
+		assert.True(t, body.closed)
+	})
+
+	t.Run("falls back to status text for empty body", func(t *testing.T) {
+		resp, _ := newTestResponse(http.StatusServiceUnavailable, "")
+		err, readErr := NewFromResponse(resp)
+		assert.NoError(t, readErr)
+		assert.Equal(t, "Service Unavailable", err.Message)
+	})
+
+	t.Run("stores response headers", func(t *testing.T) {
+		resp, _ := newTestResponse(http.StatusTooManyRequests, "")
+		err, readErr := NewFromResponse(resp)
+		assert.NoError(t, readErr)
+		headers, ok := err.Meta[ResponseHeadersMetaKey].(http.Header)
+		assert.True(t, ok)
+		assert.Equal(t, "30", headers.Get("Retry-After"))
+	})
+}
+
+func TestCheckResponse(t *testing.T) {
+	t.Run("returns nil for 200 response", func(t *testing.T) {
+		resp, body := newTestResponse(http.StatusOK, `{"ok":true}`)
+		err, readErr := CheckResponse(resp)
+		assert.NoError(t, readErr)
+		assert.Nil(t, err)
+		assert.False(t, body.closed)
+	})
+
+	t.Run("returns HTTPError for error response", func(t *testing.T) {
+		resp, _ := newTestResponse(http.StatusForbidden, "")
+		err, readErr := CheckResponse(resp)
+		assert.NoError(t, readErr)
+		assert.Equal(t, http.StatusForbidden, err.Code)
+		assert.Equal(t, "Forbidden", err.Message)
+	})
+}
+
+func TestHTTPErrorWithResponseHeaders(t *testing.T) {
+	t.Run("stores a copy of the headers", func(t *testing.T) {
+		h := http.Header{"Retry-After": {"30"}}
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable").WithResponseHeaders(h)
+		h.Set("Retry-After", "60")
+		assert.Equal(t, "30", ResponseHeaders(err).Get("Retry-After"))
+	})
+
+	t.Run("ignores nil headers", func(t *testing.T) {
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable").WithResponseHeaders(nil)
+		assert.NotContains(t, err.Meta, ResponseHeadersMetaKey)
+	})
+}
+
+func TestResponseHeaders(t *testing.T) {
+	t.Run("returns headers from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnauthorized, "unauthorized").
+			WithResponseHeaders(http.Header{"Www-Authenticate": {"Bearer"}})
+		assert.Equal(t, "Bearer", ResponseHeaders(fmt.Errorf("calling upstream: %w", err)).Get("WWW-Authenticate"))
+	})
+
+	t.Run("returns nil when not set", func(t *testing.T) {
+		assert.Nil(t, ResponseHeaders(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Nil(t, ResponseHeaders(errors.New("standard error")))
+	})
+}
+
+func TestWriteResponseForwardsResponseHeaders(t *testing.T) {
+	t.Run("forwards stored headers", func(t *testing.T) {
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable").WithResponseHeaders(http.Header{
+			"Retry-After":    {"30"},
+			"Content-Type":   {"text/html"},
+			"Content-Length": {"1234"},
+		})
+		rec := httptest.NewRecorder()
+		err.WriteResponse(rec)
+		assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+		assert.Equal(t, contentTypeJSON, rec.Header().Get("Content-Type"))
+		assert.Equal(t, strconv.Itoa(rec.Body.Len()), rec.Header().Get("Content-Length"))
+	})
+
+	t.Run("does not forward credential headers", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnauthorized, "unauthorized").WithResponseHeaders(http.Header{
+			"Set-Cookie":         {"session=secret"},
+			"Proxy-Authenticate": {"Basic"},
+			"Www-Authenticate":   {"Bearer"},
+		})
+		rec := httptest.NewRecorder()
+		err.WriteResponse(rec)
+		assert.Empty(t, rec.Header().Values("Set-Cookie"))
+		assert.Empty(t, rec.Header().Get("Proxy-Authenticate"))
+		assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("leaves stored headers out of the body", func(t *testing.T) {
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable").
+			AddMetaValue("id", "1").
+			WithResponseHeaders(http.Header{"Set-Cookie": {"session=secret"}})
+		rec := httptest.NewRecorder()
+		err.WriteResponse(rec)
+		assert.NotContains(t, rec.Body.String(), ResponseHeadersMetaKey)
+		assert.Contains(t, rec.Body.String(), `"id":"1"`)
+
+		jsonData, _ := json.Marshal(err)
+		xmlData, _ := xml.Marshal(err)
+		yamlData, _ := yaml.Marshal(err)
+		for _, body := range []string{string(jsonData), string(xmlData), string(yamlData), strings.Join(err.MarshalCSV(), ",")} {
+			assert.NotContains(t, body, ResponseHeadersMetaKey)
+			assert.NotContains(t, body, "session=secret")
+		}
+		assert.NotContains(t, ToGraphQLError(err)["extensions"].(map[string]any)["meta"], ResponseHeadersMetaKey)
+		assert.Equal(t, "session=secret", ResponseHeaders(err).Get("Set-Cookie"))
+	})
+
+	t.Run("error headers take precedence", func(t *testing.T) {
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable").
+			WithResponseHeaders(http.Header{"Retry-After": {"30"}})
+		err.Header = http.Header{"Retry-After": {"5"}}
+		rec := httptest.NewRecorder()
+		err.WriteResponse(rec)
+		assert.Equal(t, "5", rec.Header().Get("Retry-After"))
+	})
+}
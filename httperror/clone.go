@@ -0,0 +1,46 @@
+package httperror
+
+// Clone returns a copy of the HTTPError with its own Meta map and Header,
+// so that mutating the copy does not affect the original.
+// The wrapped error is shared.
+func (e *HTTPError) Clone() *HTTPError {
+	if e == nil {
+		return nil
+	}
+	clone := *e
+	clone.Meta = make(map[string]any, len(e.Meta))
+	for k, v := range e.Meta {
+		clone.Meta[k] = v
+	}
+	if e.Header != nil {
+		clone.Header = e.Header.Clone()
+	}
+	return &clone
+}
+
+// CloneWithCode returns a clone of the HTTPError with the given status code.
+func (e *HTTPError) CloneWithCode(newCode int) *HTTPError {
+	clone := e.Clone()
+	clone.Code = newCode
+	return clone
+}
+
+// CloneWithMessage returns a clone of the HTTPError with the given message.
+func (e *HTTPError) CloneWithMessage(newMsg string) *HTTPError {
+	clone := e.Clone()
+	clone.Message = newMsg
+	return clone
+}
+
+// CloneWithoutMeta returns a lightweight copy of the HTTPError for client responses.
+// Code, Message and response headers are copied; Meta is empty and no error is wrapped.
+func (e *HTTPError) CloneWithoutMeta() *HTTPError {
+	if e == nil {
+		return nil
+	}
+	clone := &HTTPError{Code: e.Code, Message: e.Message, Meta: make(map[string]any)}
+	if e.Header != nil {
+		clone.Header = e.Header.Clone()
+	}
+	return clone
+}
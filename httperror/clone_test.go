@@ -0,0 +1,102 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorClone(t *testing.T) {
+	t.Run("copies fields and wrapped error", func(t *testing.T) {
+		stdErr := errors.New("standard error")
+		original := WrapError(http.StatusBadRequest, stdErr).AddMetaValue("key", "value")
+		clone := original.Clone()
+		assert.NotSame(t, original, clone)
+		assert.Equal(t, original.Code, clone.Code)
+		assert.Equal(t, original.Message, clone.Message)
+		assert.Equal(t, original.Meta, clone.Meta)
+		assert.Equal(t, stdErr, errors.Unwrap(clone))
+	})
+
+	t.Run("mutating clone meta does not affect original", func(t *testing.T) {
+		original := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue("key", "value")
+		clone := original.Clone()
+		clone.AddMetaValue("key", "changed").AddMetaValue("other", true)
+		assert.Equal(t, map[string]any{"key": "value"}, original.Meta)
+	})
+
+	t.Run("mutating clone header does not affect original", func(t *testing.T) {
+		original := NewHTTPError(http.StatusForbidden, "forbidden").WithCORSHeaders("*")
+		clone := original.Clone()
+		clone.Header.Set("Access-Control-Allow-Origin", "https://example.com")
+		assert.Equal(t, "*", original.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		var err *HTTPError
+		assert.Nil(t, err.Clone())
+	})
+}
+
+func TestHTTPErrorCloneWithCode(t *testing.T) {
+	t.Run("replaces code only", func(t *testing.T) {
+		original := NewHTTPError(http.StatusServiceUnavailable, "unavailable").AddMetaValue("key", "value")
+		clone := original.CloneWithCode(http.StatusInternalServerError)
+		assert.Equal(t, http.StatusInternalServerError, clone.Code)
+		assert.Equal(t, "unavailable", clone.Message)
+		assert.Equal(t, original.Meta, clone.Meta)
+		assert.Equal(t, http.StatusServiceUnavailable, original.Code)
+	})
+}
+
+func TestHTTPErrorCloneWithMessage(t *testing.T) {
+	t.Run("replaces message only", func(t *testing.T) {
+		original := NewHTTPError(http.StatusNotFound, "user 123 not found")
+		clone := original.CloneWithMessage("not found")
+		assert.Equal(t, http.StatusNotFound, clone.Code)
+		assert.Equal(t, "not found", clone.Message)
+		assert.Equal(t, "user 123 not found", original.Message)
+	})
+
+	t.Run("combines with CloneWithCode", func(t *testing.T) {
+		original := NewHTTPError(http.StatusNotFound, "user 123 not found").AddMetaValue("key", "value")
+		clone := original.CloneWithCode(http.StatusGone).CloneWithMessage("gone")
+		clone.AddMetaValue("key", "changed")
+		assert.Equal(t, http.StatusGone, clone.Code)
+		assert.Equal(t, "gone", clone.Message)
+		assert.Equal(t, http.StatusNotFound, original.Code)
+		assert.Equal(t, "user 123 not found", original.Message)
+		assert.Equal(t, "value", original.Meta["key"])
+	})
+}
+
+func TestHTTPErrorCloneWithoutMeta(t *testing.T) {
+	t.Run("copies code and message only", func(t *testing.T) {
+		original := WrapError(http.StatusInternalServerError, errors.New("db password rejected")).
+			AddMetaValue("db_host", "10.0.0.1").
+			CaptureStack(0)
+		clone := original.CloneWithoutMeta()
+		assert.Equal(t, original.Code, clone.Code)
+		assert.Equal(t, original.Message, clone.Message)
+		assert.NotNil(t, clone.Meta)
+		assert.Empty(t, clone.Meta)
+		assert.Nil(t, clone.Unwrap())
+		assert.Nil(t, clone.StackTrace())
+		assert.Equal(t, "10.0.0.1", original.Meta["db_host"])
+	})
+
+	t.Run("keeps response headers", func(t *testing.T) {
+		original := NewMethodNotAllowedWithMethods(http.MethodGet)
+		clone := original.CloneWithoutMeta()
+		assert.Equal(t, "GET", clone.Header.Get("Allow"))
+		clone.Header.Set("Allow", "POST")
+		assert.Equal(t, "GET", original.Header.Get("Allow"))
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		var err *HTTPError
+		assert.Nil(t, err.CloneWithoutMeta())
+	})
+}
@@ -0,0 +1,59 @@
+package httperror
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	// CloudEventSpecVersion is the CloudEvents specification version produced by ToCloudEvent.
+	CloudEventSpecVersion = "1.0"
+	// CloudEventType is the event type produced by ToCloudEvent.
+	CloudEventType = "com.example.httperror"
+	// ComponentMetaKey is the Meta key used as the CloudEvents source.
+	ComponentMetaKey = "component"
+	// ErrorIDMetaKey is the Meta key used as the CloudEvents id.
+	ErrorIDMetaKey = "error_id"
+)
+
+// defaultCloudEventSource is used when the HTTPError has no component.
+const defaultCloudEventSource = "httperror"
+
+// ToCloudEvent converts the HTTPError into a CloudEvents v1.0 envelope.
+// The source is taken from Meta["component"] and the id from Meta["error_id"];
+// a random id is generated when none is set. The data field holds a copy of the
+// HTTPError with sensitive Meta values redacted, which encodes using its JSON
+// representation.
+func ToCloudEvent(e *HTTPError) map[string]any {
+	if e == nil {
+		return nil
+	}
+	source, _ := e.Meta[ComponentMetaKey].(string)
+	if source == "" {
+		source = defaultCloudEventSource
+	}
+	id, _ := e.Meta[ErrorIDMetaKey].(string)
+	if id == "" {
+		id = newEventID()
+	}
+	event := map[string]any{
+		"specversion":     CloudEventSpecVersion,
+		"type":            CloudEventType,
+		"source":          source,
+		"id":              id,
+		"datacontenttype": "application/json",
+		"data":            e.Redacted(),
+	}
+	if ts, ok := e.Timestamp(); ok {
+		event["time"] = ts.UTC().Format(time.RFC3339Nano)
+	}
+	return event
+}
+
+// newEventID returns a random hex-encoded event id.
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
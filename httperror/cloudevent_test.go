@@ -0,0 +1,53 @@
+package httperror
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToCloudEvent(t *testing.T) {
+	t.Run("builds CloudEvents envelope", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found").
+			AddMetaValue(ComponentMetaKey, "billing").
+			AddMetaValue(ErrorIDMetaKey, "err-123")
+		event := ToCloudEvent(err)
+		assert.Equal(t, "1.0", event["specversion"])
+		assert.Equal(t, "com.example.httperror", event["type"])
+		assert.Equal(t, "billing", event["source"])
+		assert.Equal(t, "err-123", event["id"])
+		assert.Equal(t, err, event["data"])
+	})
+
+	t.Run("encodes data as HTTPError JSON", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue(ErrorIDMetaKey, "err-123")
+		data, marshalErr := json.Marshal(ToCloudEvent(err))
+		assert.NoError(t, marshalErr)
+
+		var decoded struct {
+			Data HTTPError `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, http.StatusNotFound, decoded.Data.Code)
+		assert.Equal(t, "not found", decoded.Data.Message)
+	})
+
+	t.Run("fills in missing source and id", func(t *testing.T) {
+		event := ToCloudEvent(NewHTTPError(http.StatusBadRequest, "bad request"))
+		assert.Equal(t, "httperror", event["source"])
+		assert.Len(t, event["id"], 32)
+	})
+
+	t.Run("includes time when timestamp is set", func(t *testing.T) {
+		ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		event := ToCloudEvent(NewHTTPError(http.StatusBadRequest, "bad request").WithTimestamp(ts))
+		assert.Equal(t, "2024-01-02T03:04:05Z", event["time"])
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, ToCloudEvent(nil))
+	})
+}
@@ -0,0 +1,72 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+var (
+	codeTextsMu sync.RWMutex
+	codeTexts   = make(map[int]string)
+)
+
+// RegisterCode associates a status code, typically a custom code outside the
+// HTTP range such as 10001, with a human-readable text. NewHTTPError uses the
+// text when called with the code and an empty message. Codes outside 100-599
+// cannot be sent as an HTTP status, so the response writers send the package
+// default code in the status line and keep the custom code in the body.
+func RegisterCode(code int, text string) {
+	codeTextsMu.Lock()
+	defer codeTextsMu.Unlock()
+	codeTexts[code] = text
+}
+
+// UnregisterCode removes the text registered for code.
+func UnregisterCode(code int) {
+	codeTextsMu.Lock()
+	defer codeTextsMu.Unlock()
+	delete(codeTexts, code)
+}
+
+// RegisteredCodes returns the registered codes that are not standard HTTP status codes, sorted.
+func RegisteredCodes() []int {
+	codeTextsMu.RLock()
+	defer codeTextsMu.RUnlock()
+	codes := make([]int, 0, len(codeTexts))
+	for code := range codeTexts {
+		if http.StatusText(code) == "" {
+			codes = append(codes, code)
+		}
+	}
+	sort.Ints(codes)
+	return codes
+}
+
+// registeredCodeText returns the text registered for code.
+func registeredCodeText(code int) (string, bool) {
+	codeTextsMu.RLock()
+	defer codeTextsMu.RUnlock()
+	text, ok := codeTexts[code]
+	return text, ok
+}
+
+// CodeText returns the registered text for the status code of the HTTPError in
+// err's chain, falling back to http.StatusText, or an empty string if the code
+// is unknown. Errors that are not HTTPErrors return the text for 500 and nil
+// returns an empty string.
+func CodeText(err error) string {
+	if err == nil {
+		return ""
+	}
+	code := http.StatusInternalServerError
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		code = httpErr.Code
+	}
+	if text, ok := registeredCodeText(code); ok {
+		return text
+	}
+	return http.StatusText(code)
+}
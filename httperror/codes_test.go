@@ -0,0 +1,109 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterCode(t *testing.T) {
+	t.Run("registers text for custom code", func(t *testing.T) {
+		RegisterCode(10001, "subscription expired")
+		defer UnregisterCode(10001)
+
+		assert.Equal(t, "subscription expired", CodeText(NewHTTPError(10001, "expired")))
+	})
+
+	t.Run("overrides standard text", func(t *testing.T) {
+		RegisterCode(http.StatusTeapot, "teapot")
+		defer UnregisterCode(http.StatusTeapot)
+
+		assert.Equal(t, "teapot", CodeText(NewHTTPError(http.StatusTeapot, "short and stout")))
+	})
+
+	t.Run("auto-fills empty message", func(t *testing.T) {
+		RegisterCode(10001, "subscription expired")
+		defer UnregisterCode(10001)
+
+		assert.Equal(t, "subscription expired", NewHTTPError(10001, "").Message)
+		assert.Equal(t, "custom", NewHTTPError(10001, "custom").Message)
+		assert.Equal(t, "", NewHTTPError(10002, "").Message)
+	})
+
+	t.Run("writes custom codes in the body with the default status", func(t *testing.T) {
+		RegisterCode(10001, "subscription expired")
+		defer UnregisterCode(10001)
+
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, NewHTTPError(10001, ""))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"code":10001,"message":"subscription expired","meta":{},"schema_version":"1"}`, rec.Body.String())
+
+		rec = httptest.NewRecorder()
+		assert.NoError(t, WriteResponse(rec, httptest.NewRequest(http.MethodGet, "/", nil), NewHTTPError(10001, "")))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"code":10001,"message":"subscription expired","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("is safe for concurrent reads during writes", func(t *testing.T) {
+		defer UnregisterCode(10003)
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				RegisterCode(10003, fmt.Sprintf("text %d", i))
+			}()
+			go func() {
+				defer wg.Done()
+				_ = CodeText(NewHTTPError(10003, ""))
+				_ = RegisteredCodes()
+			}()
+		}
+		wg.Wait()
+		assert.Contains(t, CodeText(NewHTTPError(10003, "")), "text ")
+	})
+}
+
+func TestUnregisterCode(t *testing.T) {
+	t.Run("removes registered text", func(t *testing.T) {
+		RegisterCode(10001, "subscription expired")
+		UnregisterCode(10001)
+
+		assert.Equal(t, "", CodeText(NewHTTPError(10001, "expired")))
+		assert.NotContains(t, RegisteredCodes(), 10001)
+	})
+}
+
+func TestRegisteredCodes(t *testing.T) {
+	t.Run("returns sorted custom codes", func(t *testing.T) {
+		RegisterCode(10002, "b")
+		RegisterCode(10001, "a")
+		RegisterCode(http.StatusTeapot, "teapot")
+		defer UnregisterCode(10001)
+		defer UnregisterCode(10002)
+		defer UnregisterCode(http.StatusTeapot)
+
+		assert.Equal(t, []int{10001, 10002}, RegisteredCodes())
+	})
+}
+
+func TestCodeText(t *testing.T) {
+	t.Run("falls back to standard status text", func(t *testing.T) {
+		assert.Equal(t, "Not Found", CodeText(fmt.Errorf("handler: %w", NewHTTPError(http.StatusNotFound, "x"))))
+	})
+
+	t.Run("returns empty string for unknown code", func(t *testing.T) {
+		assert.Equal(t, "", CodeText(NewHTTPError(10009, "x")))
+	})
+
+	t.Run("handles nil and plain errors", func(t *testing.T) {
+		assert.Equal(t, "", CodeText(nil))
+		assert.Equal(t, "Internal Server Error", CodeText(errors.New("plain")))
+	})
+}
@@ -0,0 +1,42 @@
+package httperror
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// ETagMetaKey is the Meta key used to store the current ETag of a resource.
+const ETagMetaKey = "etag"
+
+// WithETag records the current ETag of the resource in Meta and in the ETag
+// response header, so that clients can retry a conditional request.
+func (e *HTTPError) WithETag(etag string) *HTTPError {
+	e.setHeader("ETag", etag)
+	return e.AddMetaValue(ETagMetaKey, etag)
+}
+
+// GetETag returns the ETag recorded on the HTTPError in err's chain.
+func GetETag(err error) string {
+	return metaString(err, ETagMetaKey)
+}
+
+// NewPreconditionFailed creates a 412 HTTPError recording the current ETag of the resource.
+func NewPreconditionFailed(currentETag string) *HTTPError {
+	return newHTTPError(http.StatusPreconditionFailed, http.StatusText(http.StatusPreconditionFailed), nil, func(e *HTTPError) {
+		e.WithETag(currentETag)
+	})
+}
+
+// WithContentRange sets the Content-Range response header to "bytes */totalSize",
+// as required for 416 Range Not Satisfiable responses.
+func (e *HTTPError) WithContentRange(totalSize int64) *HTTPError {
+	e.setHeader("Content-Range", "bytes */"+strconv.FormatInt(totalSize, 10))
+	return e
+}
+
+// NewRangeNotSatisfiable creates a 416 HTTPError reporting the total size of the resource.
+func NewRangeNotSatisfiable(totalSize int64) *HTTPError {
+	return newHTTPError(http.StatusRequestedRangeNotSatisfiable, http.StatusText(http.StatusRequestedRangeNotSatisfiable), nil, func(e *HTTPError) {
+		e.WithContentRange(totalSize)
+	})
+}
@@ -0,0 +1,69 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorWithETag(t *testing.T) {
+	t.Run("stores ETag in meta and header", func(t *testing.T) {
+		err := NewHTTPError(http.StatusConflict, "conflict").WithETag(`"v2"`)
+		assert.Equal(t, `"v2"`, err.Meta[ETagMetaKey])
+		assert.Equal(t, `"v2"`, err.Header.Get("ETag"))
+	})
+
+	t.Run("is written as response header", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewHTTPError(http.StatusConflict, "conflict").WithETag(`"v2"`).WriteResponse(rec)
+		assert.Equal(t, `"v2"`, rec.Header().Get("ETag"))
+	})
+}
+
+func TestGetETag(t *testing.T) {
+	t.Run("returns ETag from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusConflict, "conflict").WithETag(`"v2"`)
+		assert.Equal(t, `"v2"`, GetETag(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", GetETag(NewHTTPError(http.StatusConflict, "conflict")))
+		assert.Equal(t, "", GetETag(errors.New("standard error")))
+	})
+}
+
+func TestNewPreconditionFailed(t *testing.T) {
+	t.Run("creates 412 with ETag", func(t *testing.T) {
+		err := NewPreconditionFailed(`W/"abc"`)
+		assert.Equal(t, http.StatusPreconditionFailed, err.Code)
+		assert.Equal(t, "Precondition Failed", err.Message)
+		assert.Equal(t, `W/"abc"`, GetETag(err))
+		assert.Equal(t, `W/"abc"`, err.Header.Get("ETag"))
+	})
+}
+
+func TestHTTPErrorWithContentRange(t *testing.T) {
+	t.Run("sets Content-Range header", func(t *testing.T) {
+		err := NewHTTPError(http.StatusRequestedRangeNotSatisfiable, "bad range").WithContentRange(1024)
+		assert.Equal(t, "bytes */1024", err.Header.Get("Content-Range"))
+	})
+
+	t.Run("is written as response header", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewHTTPError(http.StatusRequestedRangeNotSatisfiable, "bad range").WithContentRange(0).WriteResponse(rec)
+		assert.Equal(t, "bytes */0", rec.Header().Get("Content-Range"))
+	})
+}
+
+func TestNewRangeNotSatisfiable(t *testing.T) {
+	t.Run("creates 416 with Content-Range", func(t *testing.T) {
+		err := NewRangeNotSatisfiable(5000)
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, err.Code)
+		assert.Equal(t, "Requested Range Not Satisfiable", err.Message)
+		assert.Equal(t, "bytes */5000", err.Header.Get("Content-Range"))
+	})
+}
@@ -0,0 +1,30 @@
+package httperror
+
+import (
+	"net/http"
+	"strings"
+)
+
+// WithCORSHeaders sets the CORS response headers allowing origin and, if given, methods.
+func (e *HTTPError) WithCORSHeaders(origin string, methods ...string) *HTTPError {
+	e.setHeader("Access-Control-Allow-Origin", origin)
+	if len(methods) > 0 {
+		e.setHeader("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	}
+	if origin != "*" {
+		e.setHeader("Vary", "Origin")
+	}
+	return e
+}
+
+// WriteCORSErrorResponse writes e as a JSON response with its CORS headers applied.
+// Headers requested by a preflight request are echoed back as allowed headers.
+func WriteCORSErrorResponse(w http.ResponseWriter, r *http.Request, e *HTTPError) {
+	if e == nil {
+		return
+	}
+	if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" && e.Header.Get("Access-Control-Allow-Origin") != "" {
+		w.Header().Set("Access-Control-Allow-Headers", requested)
+	}
+	writeJSON(w, e)
+}
@@ -0,0 +1,52 @@
+package httperror
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorWithCORSHeaders(t *testing.T) {
+	t.Run("sets origin and methods", func(t *testing.T) {
+		err := NewHTTPError(http.StatusForbidden, "forbidden").
+			WithCORSHeaders("https://example.com", http.MethodGet, http.MethodPost)
+		assert.Equal(t, "https://example.com", err.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST", err.Header.Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Origin", err.Header.Get("Vary"))
+	})
+
+	t.Run("omits methods and vary for wildcard origin", func(t *testing.T) {
+		err := NewHTTPError(http.StatusForbidden, "forbidden").WithCORSHeaders("*")
+		assert.Equal(t, "*", err.Header.Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, err.Header.Get("Access-Control-Allow-Methods"))
+		assert.Empty(t, err.Header.Get("Vary"))
+	})
+}
+
+func TestWriteCORSErrorResponse(t *testing.T) {
+	t.Run("writes JSON response with CORS headers", func(t *testing.T) {
+		err := NewHTTPError(http.StatusForbidden, "forbidden").WithCORSHeaders("https://example.com", http.MethodGet)
+		r := httptest.NewRequest(http.MethodOptions, "/", nil)
+		r.Header.Set("Access-Control-Request-Headers", "Content-Type")
+		rec := httptest.NewRecorder()
+
+		WriteCORSErrorResponse(rec, r, err)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET", rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"code":403,"message":"forbidden","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("does not allow headers without CORS headers", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodOptions, "/", nil)
+		r.Header.Set("Access-Control-Request-Headers", "Content-Type")
+		rec := httptest.NewRecorder()
+
+		WriteCORSErrorResponse(rec, r, NewHTTPError(http.StatusForbidden, "forbidden"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Headers"))
+	})
+}
@@ -0,0 +1,47 @@
+package httperror
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// csvHeader is the header row written by WriteCSV.
+var csvHeader = []string{"code", "message", "meta"}
+
+// MarshalCSV returns the HTTPError as a CSV row of code, message and JSON-encoded meta.
+// Meta values that JSON cannot represent, such as NaN or a func, are formatted as strings.
+func (e *HTTPError) MarshalCSV() []string {
+	meta := "{}"
+	if m := encodableMeta(e.Meta); len(m) > 0 {
+		for k, v := range m {
+			if _, err := json.Marshal(v); err != nil {
+				m[k] = fmt.Sprint(v)
+			}
+		}
+		if data, err := json.Marshal(m); err == nil {
+			meta = string(data)
+		}
+	}
+	return []string{strconv.Itoa(e.Code), e.Message, meta}
+}
+
+// WriteCSV writes a header row followed by one row per HTTPError to w.
+func WriteCSV(w io.Writer, errs []*HTTPError) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		if err := writer.Write(e.MarshalCSV()); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
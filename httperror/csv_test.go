@@ -0,0 +1,54 @@
+package httperror
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorMarshalCSV(t *testing.T) {
+	t.Run("returns code, message and meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("user_id", "123")
+		assert.Equal(t, []string{"404", "not found", `{"user_id":"123"}`}, err.MarshalCSV())
+	})
+
+	t.Run("formats values JSON cannot encode as strings", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").
+			AddMetaValue("user_id", "123").
+			AddMetaValue("ratio", math.NaN()).
+			AddMetaValue("ch", make(chan int))
+		meta := err.MarshalCSV()[2]
+		assert.Contains(t, meta, `"user_id":"123"`)
+		assert.Contains(t, meta, `"ratio":"NaN"`)
+		assert.Contains(t, meta, `"ch":"0x`)
+	})
+
+	t.Run("encodes empty meta as empty object", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request")
+		assert.Equal(t, []string{"400", "bad request", "{}"}, err.MarshalCSV())
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	t.Run("writes header and rows", func(t *testing.T) {
+		var buf bytes.Buffer
+		errs := []*HTTPError{
+			NewHTTPError(http.StatusBadRequest, "bad request"),
+			NewHTTPError(http.StatusInternalServerError, "boom, again").AddMetaValue("retry", true),
+		}
+		assert.NoError(t, WriteCSV(&buf, errs))
+		expected := "code,message,meta\n" +
+			"400,bad request,{}\n" +
+			"500,\"boom, again\",\"{\"\"retry\"\":true}\"\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("writes only header for empty list", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, WriteCSV(&buf, nil))
+		assert.Equal(t, "code,message,meta\n", buf.String())
+	})
+}
@@ -0,0 +1,93 @@
+package httperror
+
+import (
+	"net/http"
+	"sync"
+)
+
+var (
+	defaultMetaMu sync.RWMutex
+	defaultMeta   = make(map[string]any)
+
+	defaultCodeMu sync.RWMutex
+	defaultCode   = http.StatusInternalServerError
+)
+
+// SetDefaultCode sets the status code used when an error has none, such as by
+// WrapError with a code of 0 and ToHTTPError for errors that are not HTTPErrors.
+func SetDefaultCode(code int) {
+	defaultCodeMu.Lock()
+	defer defaultCodeMu.Unlock()
+	defaultCode = code
+}
+
+// GetDefaultCode returns the status code set by SetDefaultCode, 500 by default.
+func GetDefaultCode() int {
+	defaultCodeMu.RLock()
+	defer defaultCodeMu.RUnlock()
+	return defaultCode
+}
+
+// ResetDefaultCode restores the default status code to 500.
+func ResetDefaultCode() {
+	SetDefaultCode(http.StatusInternalServerError)
+}
+
+// setDefaultMetaValue sets a Meta value attached to every new error.
+// An empty value removes the default.
+func setDefaultMetaValue(key, value string) {
+	defaultMetaMu.Lock()
+	defer defaultMetaMu.Unlock()
+	if value == "" {
+		delete(defaultMeta, key)
+		return
+	}
+	defaultMeta[key] = value
+}
+
+// applyDefaultMeta copies the package default Meta values into e.
+func applyDefaultMeta(e *HTTPError) {
+	defaultMetaMu.RLock()
+	defer defaultMetaMu.RUnlock()
+	for k, v := range defaultMeta {
+		e.Meta[k] = v
+	}
+}
+
+// SetDefaultEnvironment sets the environment attached to every new error.
+// An empty env stops attaching an environment.
+func SetDefaultEnvironment(env string) {
+	setDefaultMetaValue(EnvironmentMetaKey, env)
+}
+
+// SetDefaultAppVersion sets the application version attached to every new error.
+// An empty version stops attaching a version.
+func SetDefaultAppVersion(version string) {
+	setDefaultMetaValue(AppVersionMetaKey, version)
+}
+
+// SetDefaultServiceName sets the service name attached to every new error.
+// An empty name stops attaching a service name.
+func SetDefaultServiceName(name string) {
+	setDefaultMetaValue(ServiceNameMetaKey, name)
+}
+
+// SetDefaults fills the zero fields of the HTTPError, such as after decoding it:
+// a Code of 0 becomes the default code, an empty Message becomes the text for
+// the code and a nil Meta is initialized.
+func (e *HTTPError) SetDefaults() *HTTPError {
+	if e.Code == 0 {
+		e.Code = GetDefaultCode()
+	}
+	if e.Message == "" {
+		if text, ok := registeredCodeText(e.Code); ok {
+			e.Message = text
+		} else {
+			e.Message = http.StatusText(e.Code)
+		}
+	}
+	if e.Meta == nil {
+		e.Meta = make(map[string]any)
+	}
+	return e
+}
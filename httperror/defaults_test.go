@@ -0,0 +1,171 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDefaultEnvironment(t *testing.T) {
+	t.Run("attaches environment to new errors", func(t *testing.T) {
+		SetDefaultEnvironment("staging")
+		defer SetDefaultEnvironment("")
+
+		assert.Equal(t, "staging", GetEnvironment(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "staging", GetEnvironment(WrapError(http.StatusBadRequest, errors.New("standard error"))))
+	})
+
+	t.Run("can be overridden per error", func(t *testing.T) {
+		SetDefaultEnvironment("staging")
+		defer SetDefaultEnvironment("")
+
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithEnvironment("prod")
+		assert.Equal(t, "prod", GetEnvironment(err))
+	})
+
+	t.Run("empty environment stops attaching", func(t *testing.T) {
+		SetDefaultEnvironment("staging")
+		SetDefaultEnvironment("")
+
+		err := NewHTTPError(http.StatusBadRequest, "bad request")
+		assert.NotContains(t, err.Meta, EnvironmentMetaKey)
+	})
+}
+
+func TestSetDefaultAppVersion(t *testing.T) {
+	t.Run("attaches app version to new errors", func(t *testing.T) {
+		SetDefaultAppVersion("1.4.2")
+		defer SetDefaultAppVersion("")
+
+		assert.Equal(t, "1.4.2", GetAppVersion(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "1.4.2", GetAppVersion(WrapError(http.StatusBadRequest, errors.New("standard error"))))
+	})
+
+	t.Run("empty version stops attaching", func(t *testing.T) {
+		SetDefaultAppVersion("1.4.2")
+		SetDefaultAppVersion("")
+
+		assert.NotContains(t, NewHTTPError(http.StatusBadRequest, "bad request").Meta, AppVersionMetaKey)
+	})
+}
+
+func TestSetDefaultServiceName(t *testing.T) {
+	t.Run("attaches service name to new errors", func(t *testing.T) {
+		SetDefaultServiceName("billing")
+		defer SetDefaultServiceName("")
+
+		assert.Equal(t, "billing", GetServiceName(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "billing", GetServiceName(WrapError(http.StatusBadRequest, errors.New("standard error"))))
+	})
+
+	t.Run("can be overridden per error", func(t *testing.T) {
+		SetDefaultServiceName("billing")
+		defer SetDefaultServiceName("")
+
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithServiceName("payments")
+		assert.Equal(t, "payments", GetServiceName(err))
+	})
+
+	t.Run("empty name stops attaching", func(t *testing.T) {
+		SetDefaultServiceName("billing")
+		SetDefaultServiceName("")
+
+		assert.Equal(t, "", GetServiceName(NewHTTPError(http.StatusBadRequest, "bad request")))
+	})
+}
+
+func TestGetDefaultCode(t *testing.T) {
+	t.Run("defaults to 500", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, GetDefaultCode())
+	})
+}
+
+func TestSetDefaultCode(t *testing.T) {
+	t.Run("is used by WrapError, ToHTTPError and NewWithOptions", func(t *testing.T) {
+		SetDefaultCode(http.StatusServiceUnavailable)
+		defer ResetDefaultCode()
+
+		assert.Equal(t, http.StatusServiceUnavailable, GetDefaultCode())
+		assert.Equal(t, http.StatusServiceUnavailable, WrapError(0, errors.New("boom")).Code)
+		assert.Equal(t, http.StatusServiceUnavailable, WrapErrorf(0, errors.New("boom"), "failed").Code)
+		assert.Equal(t, http.StatusServiceUnavailable, ToHTTPError(errors.New("boom")).Code)
+		assert.Equal(t, http.StatusServiceUnavailable, NewWithOptions().Code)
+	})
+
+	t.Run("does not override explicit codes", func(t *testing.T) {
+		SetDefaultCode(http.StatusServiceUnavailable)
+		defer ResetDefaultCode()
+
+		assert.Equal(t, http.StatusBadGateway, WrapError(http.StatusBadGateway, errors.New("boom")).Code)
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		defer ResetDefaultCode()
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				SetDefaultCode(http.StatusInternalServerError + i)
+			}()
+			go func() {
+				defer wg.Done()
+				_ = WrapError(0, errors.New("boom"))
+				_ = GetDefaultCode()
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestResetDefaultCode(t *testing.T) {
+	t.Run("restores 500", func(t *testing.T) {
+		SetDefaultCode(http.StatusServiceUnavailable)
+		ResetDefaultCode()
+
+		assert.Equal(t, http.StatusInternalServerError, GetDefaultCode())
+		assert.Equal(t, http.StatusInternalServerError, WrapError(0, errors.New("boom")).Code)
+	})
+}
+
+func TestHTTPErrorSetDefaults(t *testing.T) {
+	t.Run("fills zero fields", func(t *testing.T) {
+		err := (&HTTPError{}).SetDefaults()
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		assert.Equal(t, "Internal Server Error", err.Message)
+		assert.NotNil(t, err.Meta)
+	})
+
+	t.Run("uses configured default code", func(t *testing.T) {
+		SetDefaultCode(http.StatusServiceUnavailable)
+		defer ResetDefaultCode()
+
+		err := (&HTTPError{}).SetDefaults()
+		assert.Equal(t, http.StatusServiceUnavailable, err.Code)
+		assert.Equal(t, "Service Unavailable", err.Message)
+	})
+
+	t.Run("uses registered code text", func(t *testing.T) {
+		RegisterCode(10001, "subscription expired")
+		defer UnregisterCode(10001)
+
+		assert.Equal(t, "subscription expired", (&HTTPError{Code: 10001}).SetDefaults().Message)
+	})
+
+	t.Run("keeps set fields", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "user not found").AddMetaValue("id", "1")
+		assert.Same(t, err, err.SetDefaults())
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		assert.Equal(t, "user not found", err.Message)
+		assert.Equal(t, "1", err.Meta["id"])
+	})
+
+	t.Run("fills fields after decoding", func(t *testing.T) {
+		err, decodeErr := NewHTTPErrorFromJSON([]byte(`{"code":404}`))
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, "Not Found", err.SetDefaults().Message)
+	})
+}
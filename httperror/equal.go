@@ -0,0 +1,35 @@
+package httperror
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Equal reports whether e and other have the same Code, Message and Meta.
+// Meta is compared with reflect.DeepEqual, treating nil and empty Meta as equal.
+// The wrapped errors are not compared.
+func (e *HTTPError) Equal(other *HTTPError) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+	if e.Code != other.Code || e.Message != other.Message {
+		return false
+	}
+	if len(e.Meta) == 0 && len(other.Meta) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(e.Meta, other.Meta)
+}
+
+// Equivalent reports whether the first HTTPErrors in the chains of a and b are Equal.
+// Two nil errors are equivalent; otherwise both chains must contain an HTTPError.
+func Equivalent(a, b error) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	var httpA, httpB *HTTPError
+	if !errors.As(a, &httpA) || !errors.As(b, &httpB) {
+		return false
+	}
+	return httpA.Equal(httpB)
+}
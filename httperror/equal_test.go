@@ -0,0 +1,76 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorEqual(t *testing.T) {
+	t.Run("identical errors are equal", func(t *testing.T) {
+		a := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "1")
+		b := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "1")
+		assert.True(t, a.Equal(b))
+	})
+
+	t.Run("ignores wrapped errors", func(t *testing.T) {
+		a := WrapError(http.StatusInternalServerError, errors.New("boom"))
+		b := WrapError(http.StatusInternalServerError, errors.New("boom"))
+		assert.True(t, a.Equal(b))
+	})
+
+	t.Run("differing codes", func(t *testing.T) {
+		assert.False(t, NewHTTPError(http.StatusNotFound, "x").Equal(NewHTTPError(http.StatusGone, "x")))
+	})
+
+	t.Run("differing messages", func(t *testing.T) {
+		assert.False(t, NewHTTPError(http.StatusNotFound, "a").Equal(NewHTTPError(http.StatusNotFound, "b")))
+	})
+
+	t.Run("differing meta values", func(t *testing.T) {
+		a := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "1")
+		b := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "2")
+		assert.False(t, a.Equal(b))
+	})
+
+	t.Run("extra meta keys", func(t *testing.T) {
+		a := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "1")
+		b := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "1").AddMetaValue("extra", true)
+		assert.False(t, a.Equal(b))
+		assert.False(t, b.Equal(a))
+	})
+
+	t.Run("nil and empty meta are equal", func(t *testing.T) {
+		a := &HTTPError{Code: http.StatusNotFound, Message: "not found"}
+		assert.True(t, a.Equal(NewHTTPError(http.StatusNotFound, "not found")))
+	})
+
+	t.Run("nil inputs", func(t *testing.T) {
+		var nilErr *HTTPError
+		assert.True(t, nilErr.Equal(nil))
+		assert.False(t, nilErr.Equal(NewHTTPError(http.StatusNotFound, "not found")))
+		assert.False(t, NewHTTPError(http.StatusNotFound, "not found").Equal(nil))
+	})
+}
+
+func TestEquivalent(t *testing.T) {
+	t.Run("compares wrapped HTTPErrors", func(t *testing.T) {
+		a := fmt.Errorf("handler: %w", NewHTTPError(http.StatusNotFound, "not found"))
+		b := NewHTTPError(http.StatusNotFound, "not found")
+		assert.True(t, Equivalent(a, b))
+		assert.False(t, Equivalent(a, NewHTTPError(http.StatusGone, "not found")))
+	})
+
+	t.Run("nil inputs", func(t *testing.T) {
+		assert.True(t, Equivalent(nil, nil))
+		assert.False(t, Equivalent(nil, NewHTTPError(http.StatusNotFound, "not found")))
+		assert.False(t, Equivalent(NewHTTPError(http.StatusNotFound, "not found"), nil))
+	})
+
+	t.Run("plain errors are not equivalent", func(t *testing.T) {
+		assert.False(t, Equivalent(errors.New("a"), errors.New("a")))
+	})
+}
@@ -0,0 +1,60 @@
+package httperror
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"time"
+)
+
+func init() {
+	gob.Register(&HTTPError{})
+	// Meta value types stored by the package's own helpers.
+	gob.Register(map[string]string{})
+	gob.Register(time.Time{})
+	gob.Register(http.Header{})
+}
+
+// gobHTTPError is the gob representation of an HTTPError.
+type gobHTTPError struct {
+	Code           int
+	Message        string
+	Meta           map[string]any
+	WrappedMessage string
+}
+
+// GobEncode encodes the HTTPError for encoding/gob.
+// The wrapped error is encoded by its message. Meta values with types the
+// package does not store itself must be registered with gob.Register.
+func (e *HTTPError) GobEncode() ([]byte, error) {
+	v := gobHTTPError{Code: e.Code, Message: e.Message, Meta: e.Meta}
+	if e.err != nil {
+		v.WrappedMessage = e.err.Error()
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes the HTTPError from encoding/gob.
+// A wrapped error is reconstructed with errors.New from its message.
+func (e *HTTPError) GobDecode(data []byte) error {
+	var v gobHTTPError
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v); err != nil {
+		return err
+	}
+	if v.Meta == nil {
+		v.Meta = make(map[string]any)
+	}
+	e.Code = v.Code
+	e.Message = v.Message
+	e.Meta = v.Meta
+	e.err = nil
+	if v.WrappedMessage != "" {
+		e.err = errors.New(v.WrappedMessage)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package httperror
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorGob(t *testing.T) {
+	t.Run("round-trips all fields", func(t *testing.T) {
+		original := WrapError(http.StatusConflict, errors.New("duplicate key")).
+			AddMetaValue("user_id", "123").
+			AddMetaValue("attempt", 2)
+
+		var buf bytes.Buffer
+		assert.NoError(t, gob.NewEncoder(&buf).Encode(original))
+
+		var decoded HTTPError
+		assert.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+		assert.Equal(t, original.Code, decoded.Code)
+		assert.Equal(t, original.Message, decoded.Message)
+		assert.Equal(t, original.Meta, decoded.Meta)
+		assert.NotNil(t, decoded.Unwrap())
+		assert.Equal(t, "duplicate key", decoded.Unwrap().Error())
+	})
+
+	t.Run("leaves unwrap nil without wrapped error", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, gob.NewEncoder(&buf).Encode(NewHTTPError(http.StatusNotFound, "not found")))
+
+		var decoded HTTPError
+		assert.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+		assert.Equal(t, http.StatusNotFound, decoded.Code)
+		assert.NotNil(t, decoded.Meta)
+		assert.Nil(t, decoded.Unwrap())
+	})
+
+	t.Run("round-trips meta stored by package helpers", func(t *testing.T) {
+		errs := map[string]*HTTPError{
+			"validation errors": NewBatchValidationError(map[string]string{"email": "required"}),
+			"timestamp":         NewHTTPError(http.StatusNotFound, "not found").WithTimestamp(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			"response headers":  NewHTTPError(http.StatusBadGateway, "bad gateway").WithResponseHeaders(http.Header{"Retry-After": {"30"}}),
+			"allowed methods":   NewMethodNotAllowedWithMethods(http.MethodGet, http.MethodPost),
+		}
+		for name, original := range errs {
+			var buf bytes.Buffer
+			assert.NoError(t, gob.NewEncoder(&buf).Encode(original), name)
+
+			var decoded HTTPError
+			assert.NoError(t, gob.NewDecoder(&buf).Decode(&decoded), name)
+			assert.Equal(t, original.Meta, decoded.Meta, name)
+		}
+	})
+
+	t.Run("encodes as registered error interface", func(t *testing.T) {
+		var buf bytes.Buffer
+		var sent error = NewHTTPError(http.StatusBadRequest, "bad request")
+		assert.NoError(t, gob.NewEncoder(&buf).Encode(&sent))
+
+		var received error
+		assert.NoError(t, gob.NewDecoder(&buf).Decode(&received))
+		assert.True(t, IsBadRequest(received))
+	})
+}
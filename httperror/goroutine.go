@@ -0,0 +1,55 @@
+package httperror
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+// GoroutineIDMetaKey is the Meta key used to store the ID of the goroutine that created the error.
+const GoroutineIDMetaKey = "goroutine_id"
+
+// goroutineIDCapture controls whether WithGoroutineID records goroutine IDs.
+var goroutineIDCapture atomic.Bool
+
+// EnableGoroutineIDCapture makes the WithGoroutineID option record goroutine IDs.
+// Reading the ID parses the output of runtime.Stack, so this is intended for
+// development and test environments only.
+func EnableGoroutineIDCapture() {
+	goroutineIDCapture.Store(true)
+}
+
+// DisableGoroutineIDCapture restores the default of not recording goroutine IDs.
+func DisableGoroutineIDCapture() {
+	goroutineIDCapture.Store(false)
+}
+
+// WithGoroutineID records the ID of the goroutine calling NewWithOptions.
+// It is a no-op unless EnableGoroutineIDCapture has been called.
+func WithGoroutineID() Option {
+	return func(e *HTTPError) {
+		if !goroutineIDCapture.Load() {
+			return
+		}
+		if id, ok := goroutineID(); ok {
+			e.Meta[GoroutineIDMetaKey] = id
+		}
+	}
+}
+
+// goroutineID returns the ID of the current goroutine, parsed from the
+// "goroutine <id> [...]" header of its stack trace.
+func goroutineID() (uint64, bool) {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b, ok := bytes.CutPrefix(b, []byte("goroutine "))
+	if !ok {
+		return 0, false
+	}
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	return id, err == nil
+}
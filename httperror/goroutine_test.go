@@ -0,0 +1,35 @@
+package httperror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithGoroutineID(t *testing.T) {
+	t.Run("is a no-op by default", func(t *testing.T) {
+		err := NewWithOptions(WithGoroutineID())
+		assert.NotContains(t, err.Meta, GoroutineIDMetaKey)
+	})
+
+	t.Run("records goroutine ID when enabled", func(t *testing.T) {
+		EnableGoroutineIDCapture()
+		defer DisableGoroutineIDCapture()
+
+		id, ok := goroutineID()
+		assert.True(t, ok)
+		err := NewWithOptions(WithGoroutineID())
+		assert.Equal(t, id, err.Meta[GoroutineIDMetaKey])
+	})
+
+	t.Run("differs between goroutines", func(t *testing.T) {
+		EnableGoroutineIDCapture()
+		defer DisableGoroutineIDCapture()
+
+		here := NewWithOptions(WithGoroutineID())
+		done := make(chan *HTTPError)
+		go func() { done <- NewWithOptions(WithGoroutineID()) }()
+		there := <-done
+		assert.NotEqual(t, here.Meta[GoroutineIDMetaKey], there.Meta[GoroutineIDMetaKey])
+	})
+}
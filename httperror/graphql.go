@@ -0,0 +1,70 @@
+package httperror
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// graphQLCodes overrides the GraphQL error code derived from the HTTP status text.
+var graphQLCodes = map[int]string{
+	http.StatusBadRequest:          "BAD_USER_INPUT",
+	http.StatusUnauthorized:        "UNAUTHENTICATED",
+	http.StatusUnprocessableEntity: "BAD_USER_INPUT",
+}
+
+// GraphQLCode returns the GraphQL error code for an HTTP status code,
+// such as "NOT_FOUND" for 404 or "UNAUTHENTICATED" for 401.
+func GraphQLCode(code int) string {
+	if c, ok := graphQLCodes[code]; ok {
+		return c
+	}
+	text := http.StatusText(code)
+	if text == "" {
+		return "INTERNAL_SERVER_ERROR"
+	}
+	text = strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text)
+	return strings.ToUpper(text)
+}
+
+// ToGraphQLError converts e to a GraphQL error object. The extensions hold
+// the GraphQL error code, the HTTP status code and any metadata, with sensitive
+// Meta values redacted.
+func ToGraphQLError(e *HTTPError) map[string]any {
+	if e == nil {
+		return nil
+	}
+	e = e.Redacted()
+	extensions := map[string]any{
+		"code":   GraphQLCode(e.Code),
+		"status": e.Code,
+	}
+	if meta := encodableMeta(e.Meta); len(meta) > 0 {
+		extensions["meta"] = meta
+	}
+	return map[string]any{
+		"message":    e.Message,
+		"locations":  []any{},
+		"path":       []any{},
+		"extensions": extensions,
+	}
+}
+
+// WriteGraphQLErrorResponse writes errs as a GraphQL {"errors":[...]} envelope.
+// As is conventional for GraphQL over HTTP, the response status is 200.
+func WriteGraphQLErrorResponse(w http.ResponseWriter, errs ...*HTTPError) {
+	graphQLErrors := make([]map[string]any, 0, len(errs))
+	for _, e := range errs {
+		if e != nil {
+			graphQLErrors = append(graphQLErrors, ToGraphQLError(e))
+		}
+	}
+	data, err := json.Marshal(map[string]any{"errors": graphQLErrors})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,68 @@
+package httperror
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQLCode(t *testing.T) {
+	t.Run("maps status codes to GraphQL codes", func(t *testing.T) {
+		assert.Equal(t, "BAD_USER_INPUT", GraphQLCode(http.StatusBadRequest))
+		assert.Equal(t, "UNAUTHENTICATED", GraphQLCode(http.StatusUnauthorized))
+		assert.Equal(t, "FORBIDDEN", GraphQLCode(http.StatusForbidden))
+		assert.Equal(t, "NOT_FOUND", GraphQLCode(http.StatusNotFound))
+		assert.Equal(t, "TOO_MANY_REQUESTS", GraphQLCode(http.StatusTooManyRequests))
+		assert.Equal(t, "IM_A_TEAPOT", GraphQLCode(http.StatusTeapot))
+		assert.Equal(t, "INTERNAL_SERVER_ERROR", GraphQLCode(http.StatusInternalServerError))
+	})
+
+	t.Run("maps unknown codes to internal server error", func(t *testing.T) {
+		assert.Equal(t, "INTERNAL_SERVER_ERROR", GraphQLCode(599))
+	})
+}
+
+func TestToGraphQLError(t *testing.T) {
+	t.Run("converts HTTPError", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "user not found").AddMetaValue("user_id", "123")
+		assert.Equal(t, map[string]any{
+			"message":   "user not found",
+			"locations": []any{},
+			"path":      []any{},
+			"extensions": map[string]any{
+				"code":   "NOT_FOUND",
+				"status": http.StatusNotFound,
+				"meta":   map[string]any{"user_id": "123"},
+			},
+		}, ToGraphQLError(err))
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, ToGraphQLError(nil))
+	})
+}
+
+func TestWriteGraphQLErrorResponse(t *testing.T) {
+	t.Run("writes GraphQL error envelope", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteGraphQLErrorResponse(rec,
+			NewHTTPError(http.StatusNotFound, "user not found"),
+			nil,
+			NewHTTPError(http.StatusForbidden, "forbidden"),
+		)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"errors":[
+			{"message":"user not found","locations":[],"path":[],"extensions":{"code":"NOT_FOUND","status":404}},
+			{"message":"forbidden","locations":[],"path":[],"extensions":{"code":"FORBIDDEN","status":403}}
+		]}`, rec.Body.String())
+	})
+
+	t.Run("writes empty errors array", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteGraphQLErrorResponse(rec)
+		assert.JSONEq(t, `{"errors":[]}`, rec.Body.String())
+	})
+}
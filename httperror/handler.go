@@ -0,0 +1,95 @@
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// HTTPHandlerFunc is an HTTP handler that returns an error instead of writing it.
+// A returned error is written as the response; see HandleHTTPError.
+type HTTPHandlerFunc func(http.ResponseWriter, *http.Request) error
+
+// ErrorFormatter encodes an HTTPError as a response, returning the status code,
+// the body and the Content-Type.
+type ErrorFormatter func(*HTTPError) (int, []byte, string)
+
+var (
+	errorFormatterMu sync.RWMutex
+	errorFormatter   ErrorFormatter = formatJSON
+)
+
+// formatJSON is the default ErrorFormatter, encoding the HTTPError as JSON.
+func formatJSON(e *HTTPError) (int, []byte, string) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return http.StatusInternalServerError, []byte(http.StatusText(http.StatusInternalServerError)), "text/plain; charset=utf-8"
+	}
+	return e.Code, data, contentTypeJSON
+}
+
+// SetErrorFormatter sets the ErrorFormatter used to write errors returned by
+// an HTTPHandlerFunc. A nil formatter restores the default JSON formatter.
+func SetErrorFormatter(f ErrorFormatter) {
+	errorFormatterMu.Lock()
+	defer errorFormatterMu.Unlock()
+	if f == nil {
+		f = formatJSON
+	}
+	errorFormatter = f
+}
+
+// writeFormatted writes e to w using the configured ErrorFormatter.
+// The formatter receives a copy of e with sensitive Meta values redacted.
+func writeFormatted(w http.ResponseWriter, e *HTTPError) {
+	errorFormatterMu.RLock()
+	format := errorFormatter
+	errorFormatterMu.RUnlock()
+
+	code, body, contentType := format(e.Redacted())
+	_ = writeBody(w, e, code, contentType, body)
+}
+
+// ServeHTTP calls fn and writes the returned error, if any, using the configured
+// ErrorFormatter. Errors without an HTTPError in their chain are written with
+// the package default code; see SetDefaultCode.
+func (fn HTTPHandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := fn(w, r)
+	if err == nil {
+		return
+	}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		httpErr = WrapError(GetDefaultCode(), err)
+	}
+	writeFormatted(w, httpErr)
+}
+
+// HandleHTTPError adapts fn to an http.Handler that writes the error returned by fn.
+func HandleHTTPError(fn HTTPHandlerFunc) http.Handler {
+	return fn
+}
+
+// HTTPErrorMiddleware lets any handler abort with an HTTPError by panicking with
+// it: a panic whose value is an *HTTPError, or an error with one in its chain,
+// is recovered and written using the configured ErrorFormatter. Other panics,
+// including http.ErrAbortHandler, are re-raised. Errors returned by an
+// HTTPHandlerFunc are written as usual.
+func HTTPErrorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			err, ok := v.(error)
+			var httpErr *HTTPError
+			if !ok || !errors.As(err, &httpErr) {
+				panic(v)
+			}
+			writeFormatted(w, httpErr)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,151 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleHTTPError(t *testing.T) {
+	t.Run("writes HTTPError as JSON", func(t *testing.T) {
+		h := HandleHTTPError(func(w http.ResponseWriter, r *http.Request) error {
+			return NewHTTPError(http.StatusNotFound, "not found")
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, contentTypeJSON, rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"code":404,"message":"not found","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("unwraps wrapped HTTPError", func(t *testing.T) {
+		h := HandleHTTPError(func(w http.ResponseWriter, r *http.Request) error {
+			return fmt.Errorf("handler: %w", NewHTTPError(http.StatusConflict, "conflict"))
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("writes plain error as 500", func(t *testing.T) {
+		h := HandleHTTPError(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("boom")
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"code":500,"message":"boom","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("leaves response untouched for nil error", func(t *testing.T) {
+		h := HandleHTTPError(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("applies error headers", func(t *testing.T) {
+		h := HandleHTTPError(func(w http.ResponseWriter, r *http.Request) error {
+			return NewMethodNotAllowedWithMethods(http.MethodGet)
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET", rec.Header().Get("Allow"))
+	})
+}
+
+func TestHTTPErrorMiddleware(t *testing.T) {
+	t.Run("writes error from HTTPHandlerFunc", func(t *testing.T) {
+		h := HTTPErrorMiddleware(HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return NewHTTPError(http.StatusBadRequest, "bad request")
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.JSONEq(t, `{"code":400,"message":"bad request","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("serves plain handlers unchanged", func(t *testing.T) {
+		h := HTTPErrorMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+	})
+
+	t.Run("writes HTTPError panics from plain handlers", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(NewHTTPError(http.StatusForbidden, "forbidden"))
+		})
+		assert.Panics(t, func() {
+			next.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+
+		rec := httptest.NewRecorder()
+		HTTPErrorMiddleware(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.JSONEq(t, `{"code":403,"message":"forbidden","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("writes panics with wrapped HTTPErrors", func(t *testing.T) {
+		h := HTTPErrorMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(fmt.Errorf("loading user: %w", NewHTTPError(http.StatusNotFound, "not found")))
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("re-raises other panics", func(t *testing.T) {
+		for _, v := range []any{"boom", errors.New("boom"), http.ErrAbortHandler} {
+			h := HTTPErrorMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				panic(v)
+			}))
+			assert.PanicsWithValue(t, v, func() {
+				h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+			})
+		}
+	})
+}
+
+func TestSetErrorFormatter(t *testing.T) {
+	t.Run("uses custom formatter", func(t *testing.T) {
+		SetErrorFormatter(func(e *HTTPError) (int, []byte, string) {
+			return e.Code, []byte(e.Message), "text/plain"
+		})
+		defer SetErrorFormatter(nil)
+
+		h := HandleHTTPError(func(w http.ResponseWriter, r *http.Request) error {
+			return NewHTTPError(http.StatusTeapot, "short and stout")
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusTeapot, rec.Code)
+		assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "short and stout", rec.Body.String())
+	})
+
+	t.Run("nil restores JSON formatter", func(t *testing.T) {
+		SetErrorFormatter(func(e *HTTPError) (int, []byte, string) {
+			return e.Code, nil, "text/plain"
+		})
+		SetErrorFormatter(nil)
+
+		h := HandleHTTPError(func(w http.ResponseWriter, r *http.Request) error {
+			return NewHTTPError(http.StatusNotFound, "not found")
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, contentTypeJSON, rec.Header().Get("Content-Type"))
+	})
+}
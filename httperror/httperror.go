@@ -7,23 +7,56 @@ import (
 )
 
 // HTTPError represents an error that occurred during an HTTP request.
-// It contains the HTTP status code, a message, and optional metadata.
+// It contains the HTTP status code, a message, optional metadata, and optional
+// headers to set on the response.
 type HTTPError struct {
-	Code    int
-	Message string
-	Meta    map[string]any
-	err     error
+	Code     int
+	Message  string
+	Meta     map[string]any
+	Header   http.Header
+	err      error
+	frames   []uintptr
+	jsonBody []byte
+	xmlBody  []byte
+	// jsonBodyErr and xmlBodyErr hold the errors from encoding the pre-serialized bodies.
+	jsonBodyErr error
+	xmlBodyErr  error
+	// schemaVersion is the JSON schema version the HTTPError was decoded from.
+	schemaVersion string
+}
+
+// newHTTPError creates the HTTPError returned by the package constructors.
+// It must be called directly from the exported constructor so that an
+// automatically captured stack trace starts at the constructor's caller.
+// Constructors that add Meta or headers do so through opts, which are applied
+// before the APM hook is notified so that it sees the complete error.
+func newHTTPError(code int, message string, err error, opts ...Option) *HTTPError {
+	e := &HTTPError{Code: code, Message: message, Meta: make(map[string]any), err: err}
+	applyDefaultMeta(e)
+	if autoCapture.Load() {
+		e.frames = callers(2)
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	applyDefaultMaxMessageLength(e)
+	notifyAPM(e)
+	return e
 }
 
 // NewHTTPError creates a new HTTPError with the given status code and message.
+// An empty message is replaced by the text registered for code with RegisterCode.
 func NewHTTPError(code int, message string) *HTTPError {
-	return &HTTPError{Code: code, Message: message, Meta: make(map[string]any)}
+	if message == "" {
+		message, _ = registeredCodeText(code)
+	}
+	return newHTTPError(code, message, nil)
 }
 
 // NewHTTPErrorf creates a new HTTPError with the given status code and formatted message.
 func NewHTTPErrorf(code int, format string, args ...any) *HTTPError {
 	message := fmt.Sprintf(format, args...)
-	return &HTTPError{Code: code, Message: message, Meta: make(map[string]any)}
+	return newHTTPError(code, message, nil)
 }
 
 // Implement the Unwrap method
@@ -31,15 +64,127 @@ func (e *HTTPError) Unwrap() error {
 	return e.err
 }
 
+// Is reports whether target is an HTTPError with the same status code.
+// A target with an empty message matches any message; otherwise the messages must be equal.
+func (e *HTTPError) Is(target error) bool {
+	t, ok := target.(*HTTPError)
+	if !ok || t == nil {
+		return false
+	}
+	return e.Code == t.Code && (t.Message == "" || e.Message == t.Message)
+}
+
 // WrapError wraps an error with an HTTPError.
+// A code of 0 uses the package default code; see SetDefaultCode.
 func WrapError(code int, err error) *HTTPError {
 	if httpErr, ok := err.(*HTTPError); ok {
 		return httpErr
 	}
 	if code == 0 {
-		code = http.StatusInternalServerError
+		code = GetDefaultCode()
+	}
+	return newHTTPError(code, err.Error(), err)
+}
+
+// WrapErrorf wraps an error with an HTTPError using a formatted message.
+// If err is already an HTTPError, it is returned unchanged.
+// A code of 0 uses the package default code; see SetDefaultCode.
+func WrapErrorf(code int, err error, format string, args ...any) *HTTPError {
+	if httpErr, ok := err.(*HTTPError); ok {
+		return httpErr
+	}
+	if code == 0 {
+		code = GetDefaultCode()
+	}
+	message := fmt.Sprintf(format, args...)
+	return newHTTPError(code, message, err)
+}
+
+// NewWithCause creates a new HTTPError with the given status code and message
+// that wraps cause. Unlike WrapError, the message is independent of cause and
+// an HTTPError cause is wrapped rather than returned.
+func NewWithCause(code int, message string, cause error) *HTTPError {
+	return newHTTPError(code, message, cause)
+}
+
+// WrapIfChanged recodes err to newCode, but only when its current status code
+// differs. If the HTTPError in err's chain already has newCode, that HTTPError
+// is returned as-is so that repeated recoding does not grow the error chain.
+// An empty newMessage falls back to the message of err.
+func WrapIfChanged(err error, newCode int, newMessage string) *HTTPError {
+	if err == nil {
+		return nil
+	}
+	if httpErr, ok := AsHTTPError(err); ok && httpErr.Code == newCode {
+		return httpErr
+	}
+	if newMessage == "" {
+		newMessage = err.Error()
+	}
+	return newHTTPError(newCode, newMessage, err)
+}
+
+// OriginalCodeMetaKey is the Meta key used to store the status code of a recoded error.
+const OriginalCodeMetaKey = "_original_code"
+
+// UpgradeStatus returns a new HTTPError with newCode that keeps the message of err
+// and wraps it, e.g. to report a dependency's 503 as a 500. err is converted with
+// ToHTTPError and its code is recorded in Meta; see OriginalCode. A nil err returns nil.
+func UpgradeStatus(err error, newCode int) *HTTPError {
+	original := ToHTTPError(err)
+	if original == nil {
+		return nil
+	}
+	return newHTTPError(newCode, original.Message, original, WithMetaValue(OriginalCodeMetaKey, original.Code))
+}
+
+// DowngradeStatus is an alias for UpgradeStatus.
+func DowngradeStatus(err error, newCode int) *HTTPError {
+	return UpgradeStatus(err, newCode)
+}
+
+// OriginalCode returns the status code recorded by UpgradeStatus or DowngradeStatus
+// on the HTTPError in err's chain.
+func OriginalCode(err error) (int, bool) {
+	return metaInt(err, OriginalCodeMetaKey)
+}
+
+// Annotate wraps err with an HTTPError that keeps err's status code and
+// prefixes its message with message, like fmt.Errorf("%s: %w", message, err).
+// If err is an HTTPError, its Message and a copy of its Meta are used.
+// A nil err returns nil.
+func Annotate(err error, message string) *HTTPError {
+	if err == nil {
+		return nil
+	}
+	return newHTTPError(GetStatusCode(err), message+": "+annotatedMessage(err), err, copyAnnotatedMeta(err))
+}
+
+// Annotatef is like Annotate but formats the message.
+func Annotatef(err error, format string, args ...any) *HTTPError {
+	if err == nil {
+		return nil
+	}
+	return newHTTPError(GetStatusCode(err), fmt.Sprintf(format, args...)+": "+annotatedMessage(err), err, copyAnnotatedMeta(err))
+}
+
+// annotatedMessage returns the message of err used by Annotate.
+func annotatedMessage(err error) string {
+	if httpErr, ok := err.(*HTTPError); ok {
+		return httpErr.Message
+	}
+	return err.Error()
+}
+
+// copyAnnotatedMeta returns an Option copying the Meta of err if err is an HTTPError.
+func copyAnnotatedMeta(err error) Option {
+	return func(e *HTTPError) {
+		if httpErr, ok := err.(*HTTPError); ok {
+			for k, v := range httpErr.Meta {
+				e.Meta[k] = v
+			}
+		}
 	}
-	return &HTTPError{Code: code, Message: err.Error(), Meta: make(map[string]any), err: err}
 }
 
 // Error returns the error message as a string.
@@ -47,12 +192,60 @@ func (e *HTTPError) Error() string {
 	return fmt.Sprintf("[%d] HTTP Error: - %s", e.Code, e.Message)
 }
 
+// StatusCode returns the HTTP status code of the HTTPError. It makes HTTPError
+// compatible with HTTP clients that check errors for a StatusCode() int method.
+func (e *HTTPError) StatusCode() int {
+	return e.Code
+}
+
+// Reason returns the HTTP reason phrase for the HTTPError's status code.
+func (e *HTTPError) Reason() string {
+	return http.StatusText(e.Code)
+}
+
+// StatusText returns the standard HTTP status text for the HTTPError's status code,
+// or an empty string if the code is unknown.
+func (e *HTTPError) StatusText() string {
+	return http.StatusText(e.Code)
+}
+
+// StatusText returns the standard HTTP status text for the HTTPError in err's chain.
+// Errors that are not HTTPErrors return "Internal Server Error" and nil returns an empty string.
+func StatusText(err error) string {
+	if err == nil {
+		return ""
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusText()
+	}
+	return http.StatusText(http.StatusInternalServerError)
+}
+
 // AddMetaValue adds a metadata value to the HTTPError.
 func (e *HTTPError) AddMetaValue(key string, value any) *HTTPError {
 	e.Meta[key] = value
 	return e
 }
 
+// WithCode sets the status code of the HTTPError and returns it for chaining.
+func (e *HTTPError) WithCode(code int) *HTTPError {
+	e.Code = code
+	return e
+}
+
+// WithMessage sets the message of the HTTPError and returns it for chaining.
+func (e *HTTPError) WithMessage(msg string) *HTTPError {
+	e.Message = msg
+	return e
+}
+
+// WithErr sets the error wrapped by the HTTPError and returns it for chaining.
+func (e *HTTPError) WithErr(err error) *HTTPError {
+	e.err = err
+	return e
+}
+
 // GetStatusCode returns the HTTP status code from the provided error.
 // If the error is not an HTTPError, it returns 500.
 func GetStatusCode(err error) int {
@@ -69,6 +262,7 @@ func IsHTTPError(err error) bool {
 }
 
 // ToHTTPError converts an error to an HTTPError if it is an HTTPError.
+// Other errors are wrapped with the package default code; see SetDefaultCode.
 func ToHTTPError(err error) *HTTPError {
 	if err == nil {
 		return nil
@@ -76,7 +270,49 @@ func ToHTTPError(err error) *HTTPError {
 	if httpErr, ok := err.(*HTTPError); ok {
 		return httpErr
 	}
-	return WrapError(GetStatusCode(err), err)
+	return WrapError(GetDefaultCode(), err)
+}
+
+// AsHTTPError returns the first HTTPError in err's chain and true, or nil and
+// false if there is none. Unlike ToHTTPError, plain errors are not wrapped.
+func AsHTTPError(err error) (*HTTPError, bool) {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr == nil {
+		return nil, false
+	}
+	return httpErr, true
+}
+
+// ExtractHTTPError is an alias for AsHTTPError.
+func ExtractHTTPError(err error) (*HTTPError, bool) {
+	return AsHTTPError(err)
+}
+
+// MustHTTPError returns the HTTPError in err's chain and panics if there is none.
+func MustHTTPError(err error) *HTTPError {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		panic(fmt.Sprintf("expected *HTTPError but got %T: %v", err, err))
+	}
+	return httpErr
+}
+
+// IsContinue checks if the provided error is an HTTPError with a status code of 100.
+func IsContinue(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusContinue
+}
+
+// IsSwitchingProtocols checks if the provided error is an HTTPError with a status code of 101.
+func IsSwitchingProtocols(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusSwitchingProtocols
+}
+
+// IsEarlyHints checks if the provided error is an HTTPError with a status code of 103.
+func IsEarlyHints(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusEarlyHints
 }
 
 // IsOK checks if the provided error is an HTTPError with a status code of 200.
@@ -103,6 +339,72 @@ func IsNoContent(err error) bool {
 	return errors.As(err, &httpErr) && httpErr.Code == http.StatusNoContent
 }
 
+// IsPartialContent checks if the provided error is an HTTPError with a status code of 206.
+func IsPartialContent(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusPartialContent
+}
+
+// IsMultiStatus checks if the provided error is an HTTPError with a status code of 207.
+func IsMultiStatus(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusMultiStatus
+}
+
+// IsAlreadyReported checks if the provided error is an HTTPError with a status code of 208.
+func IsAlreadyReported(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusAlreadyReported
+}
+
+// IsIMUsed checks if the provided error is an HTTPError with a status code of 226.
+func IsIMUsed(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusIMUsed
+}
+
+// IsMultipleChoices checks if the provided error is an HTTPError with a status code of 300.
+func IsMultipleChoices(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusMultipleChoices
+}
+
+// IsMovedPermanently checks if the provided error is an HTTPError with a status code of 301.
+func IsMovedPermanently(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusMovedPermanently
+}
+
+// IsFound checks if the provided error is an HTTPError with a status code of 302.
+func IsFound(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusFound
+}
+
+// IsSeeOther checks if the provided error is an HTTPError with a status code of 303.
+func IsSeeOther(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusSeeOther
+}
+
+// IsNotModified checks if the provided error is an HTTPError with a status code of 304.
+func IsNotModified(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusNotModified
+}
+
+// IsTemporaryRedirect checks if the provided error is an HTTPError with a status code of 307.
+func IsTemporaryRedirect(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusTemporaryRedirect
+}
+
+// IsPermanentRedirect checks if the provided error is an HTTPError with a status code of 308.
+func IsPermanentRedirect(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusPermanentRedirect
+}
+
 // IsBadRequest checks if the provided error is an HTTPError with a status code of 400.
 func IsBadRequest(err error) bool {
 	var httpErr *HTTPError
@@ -115,6 +417,12 @@ func IsUnauthorized(err error) bool {
 	return errors.As(err, &httpErr) && httpErr.Code == http.StatusUnauthorized
 }
 
+// IsPaymentRequired checks if the provided error is an HTTPError with a status code of 402.
+func IsPaymentRequired(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusPaymentRequired
+}
+
 // IsForbidden checks if the provided error is an HTTPError with a status code of 403.
 func IsForbidden(err error) bool {
 	var httpErr *HTTPError
@@ -127,12 +435,162 @@ func IsNotFound(err error) bool {
 	return errors.As(err, &httpErr) && httpErr.Code == http.StatusNotFound
 }
 
+// IsMethodNotAllowed checks if the provided error is an HTTPError with a status code of 405.
+func IsMethodNotAllowed(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusMethodNotAllowed
+}
+
+// IsNotAcceptable checks if the provided error is an HTTPError with a status code of 406.
+func IsNotAcceptable(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusNotAcceptable
+}
+
+// IsProxyAuthRequired checks if the provided error is an HTTPError with a status code of 407.
+func IsProxyAuthRequired(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusProxyAuthRequired
+}
+
+// IsRequestTimeout checks if the provided error is an HTTPError with a status code of 408.
+func IsRequestTimeout(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusRequestTimeout
+}
+
+// IsConflict checks if the provided error is an HTTPError with a status code of 409.
+func IsConflict(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusConflict
+}
+
+// IsGone checks if the provided error is an HTTPError with a status code of 410.
+func IsGone(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusGone
+}
+
+// IsLengthRequired checks if the provided error is an HTTPError with a status code of 411.
+func IsLengthRequired(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusLengthRequired
+}
+
+// IsPreconditionFailed checks if the provided error is an HTTPError with a status code of 412.
+func IsPreconditionFailed(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusPreconditionFailed
+}
+
+// IsPayloadTooLarge checks if the provided error is an HTTPError with a status code of 413.
+func IsPayloadTooLarge(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusRequestEntityTooLarge
+}
+
+// IsURITooLong checks if the provided error is an HTTPError with a status code of 414.
+func IsURITooLong(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusRequestURITooLong
+}
+
+// IsUnsupportedMediaType checks if the provided error is an HTTPError with a status code of 415.
+func IsUnsupportedMediaType(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusUnsupportedMediaType
+}
+
+// IsRangeNotSatisfiable checks if the provided error is an HTTPError with a status code of 416.
+func IsRangeNotSatisfiable(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusRequestedRangeNotSatisfiable
+}
+
+// IsExpectationFailed checks if the provided error is an HTTPError with a status code of 417.
+func IsExpectationFailed(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusExpectationFailed
+}
+
+// IsTeapot checks if the provided error is an HTTPError with a status code of 418.
+func IsTeapot(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusTeapot
+}
+
+// IsMisdirectedRequest checks if the provided error is an HTTPError with a status code of 421.
+func IsMisdirectedRequest(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusMisdirectedRequest
+}
+
+// IsUnprocessableEntity checks if the provided error is an HTTPError with a status code of 422.
+func IsUnprocessableEntity(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusUnprocessableEntity
+}
+
+// IsLocked checks if the provided error is an HTTPError with a status code of 423.
+func IsLocked(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusLocked
+}
+
+// IsFailedDependency checks if the provided error is an HTTPError with a status code of 424.
+func IsFailedDependency(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusFailedDependency
+}
+
+// IsTooEarly checks if the provided error is an HTTPError with a status code of 425.
+func IsTooEarly(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusTooEarly
+}
+
+// IsUpgradeRequired checks if the provided error is an HTTPError with a status code of 426.
+func IsUpgradeRequired(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusUpgradeRequired
+}
+
+// IsPreconditionRequired checks if the provided error is an HTTPError with a status code of 428.
+func IsPreconditionRequired(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusPreconditionRequired
+}
+
+// IsTooManyRequests checks if the provided error is an HTTPError with a status code of 429.
+func IsTooManyRequests(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusTooManyRequests
+}
+
+// IsRequestHeaderFieldsTooLarge checks if the provided error is an HTTPError with a status code of 431.
+func IsRequestHeaderFieldsTooLarge(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusRequestHeaderFieldsTooLarge
+}
+
+// IsUnavailableForLegalReasons checks if the provided error is an HTTPError with a status code of 451.
+func IsUnavailableForLegalReasons(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusUnavailableForLegalReasons
+}
+
 // IsInternalServerError checks if the provided error is an HTTPError with a status code of 500.
 func IsInternalServerError(err error) bool {
 	var httpErr *HTTPError
 	return errors.As(err, &httpErr) && httpErr.Code == http.StatusInternalServerError
 }
 
+// IsNotImplemented checks if the provided error is an HTTPError with a status code of 501.
+func IsNotImplemented(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusNotImplemented
+}
+
 // IsBadGateway checks if the provided error is an HTTPError with a status code of 502.
 func IsBadGateway(err error) bool {
 	var httpErr *HTTPError
@@ -151,6 +609,42 @@ func IsGatewayTimeout(err error) bool {
 	return errors.As(err, &httpErr) && httpErr.Code == http.StatusGatewayTimeout
 }
 
+// IsHTTPVersionNotSupported checks if the provided error is an HTTPError with a status code of 505.
+func IsHTTPVersionNotSupported(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusHTTPVersionNotSupported
+}
+
+// IsVariantAlsoNegotiates checks if the provided error is an HTTPError with a status code of 506.
+func IsVariantAlsoNegotiates(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusVariantAlsoNegotiates
+}
+
+// IsInsufficientStorage checks if the provided error is an HTTPError with a status code of 507.
+func IsInsufficientStorage(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusInsufficientStorage
+}
+
+// IsLoopDetected checks if the provided error is an HTTPError with a status code of 508.
+func IsLoopDetected(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusLoopDetected
+}
+
+// IsNotExtended checks if the provided error is an HTTPError with a status code of 510.
+func IsNotExtended(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusNotExtended
+}
+
+// IsNetworkAuthenticationRequired checks if the provided error is an HTTPError with a status code of 511.
+func IsNetworkAuthenticationRequired(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusNetworkAuthenticationRequired
+}
+
 // IsStatus checks if the provided error is an HTTPError with the specified status code.
 func IsStatus(err error, status int) bool {
 	var httpErr *HTTPError
@@ -181,8 +675,70 @@ func IsSuccess(err error) bool {
 	return errors.As(err, &httpErr) && httpErr.Code >= http.StatusOK && httpErr.Code < http.StatusMultipleChoices
 }
 
+// isNilError reports whether err is nil or a nil *HTTPError.
+func isNilError(err error) bool {
+	if err == nil {
+		return true
+	}
+	httpErr, ok := err.(*HTTPError)
+	return ok && httpErr == nil
+}
+
+// IsNilOrSuccess checks if the provided error is nil, including a nil *HTTPError,
+// or an HTTPError with a status code of 2XX.
+func IsNilOrSuccess(err error) bool {
+	return isNilError(err) || IsSuccess(err)
+}
+
+// MustBeSuccess panics unless IsNilOrSuccess(err) is true. The panic value is
+// always an *HTTPError so that it can be handled by RecoverHTTPError.
+func MustBeSuccess(err error) {
+	if IsNilOrSuccess(err) {
+		return
+	}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		httpErr = WrapError(GetDefaultCode(), err)
+	}
+	panic(httpErr)
+}
+
 // IsRedirect checks if the provided error is an HTTPError with a status code of 3XX.
 func IsRedirect(err error) bool {
 	var httpErr *HTTPError
 	return errors.As(err, &httpErr) && httpErr.Code >= http.StatusMultipleChoices && httpErr.Code < http.StatusBadRequest
 }
+
+// statusCodeIn returns the status code of the HTTPError in err's chain and
+// whether it is a valid status code between 100 and 599.
+func statusCodeIn(err error) (int, bool) {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code < 100 || httpErr.Code > 599 {
+		return 0, false
+	}
+	return httpErr.Code, true
+}
+
+// IsInRange checks if the provided error is an HTTPError with a status code in [low, high).
+func IsInRange(err error, low, high int) bool {
+	code, ok := statusCodeIn(err)
+	return ok && code >= low && code < high
+}
+
+// IsInClosedRange checks if the provided error is an HTTPError with a status code in [low, high].
+func IsInClosedRange(err error, low, high int) bool {
+	code, ok := statusCodeIn(err)
+	return ok && code >= low && code <= high
+}
+
+// IsAboveCode checks if the provided error is an HTTPError with a status code greater than code.
+func IsAboveCode(err error, code int) bool {
+	c, ok := statusCodeIn(err)
+	return ok && c > code
+}
+
+// IsBelowCode checks if the provided error is an HTTPError with a status code less than code.
+func IsBelowCode(err error, code int) bool {
+	c, ok := statusCodeIn(err)
+	return ok && c < code
+}
@@ -2,6 +2,7 @@ package httperror
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -25,6 +26,39 @@ func TestNewHTTPErrorf(t *testing.T) {
 	})
 }
 
+func TestHTTPErrorIs(t *testing.T) {
+	t.Run("matches same code with empty target message", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "user not found")
+		assert.True(t, errors.Is(err, NewHTTPError(http.StatusNotFound, "")))
+	})
+
+	t.Run("matches same code and exact message", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "user not found")
+		assert.True(t, errors.Is(err, NewHTTPError(http.StatusNotFound, "user not found")))
+		assert.False(t, errors.Is(err, NewHTTPError(http.StatusNotFound, "order not found")))
+	})
+
+	t.Run("does not match different code", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "user not found")
+		assert.False(t, errors.Is(err, NewHTTPError(http.StatusBadRequest, "")))
+	})
+
+	t.Run("does not match non-HTTPError target", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "user not found")
+		assert.False(t, errors.Is(err, errors.New("user not found")))
+	})
+
+	t.Run("matches same pointer", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "user not found")
+		assert.True(t, errors.Is(err, err))
+	})
+
+	t.Run("matches through wrapped chain", func(t *testing.T) {
+		err := fmt.Errorf("handler: %w", NewHTTPError(http.StatusNotFound, "user not found"))
+		assert.True(t, errors.Is(err, NewHTTPError(http.StatusNotFound, "")))
+	})
+}
+
 func TestWrapError(t *testing.T) {
 	t.Run("wraps standard error", func(t *testing.T) {
 		stdErr := errors.New("standard error")
@@ -47,6 +81,163 @@ func TestWrapError(t *testing.T) {
 	})
 }
 
+func TestWrapErrorf(t *testing.T) {
+	t.Run("wraps error with formatted message", func(t *testing.T) {
+		stdErr := errors.New("standard error")
+		httpErr := WrapErrorf(http.StatusNotFound, stdErr, "user %d not found in %s", 123, "accounts")
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+		assert.Equal(t, "user 123 not found in accounts", httpErr.Message)
+		assert.Equal(t, stdErr, errors.Unwrap(httpErr))
+	})
+
+	t.Run("returns existing HTTPError", func(t *testing.T) {
+		existingErr := NewHTTPError(http.StatusNotFound, "not found")
+		httpErr := WrapErrorf(http.StatusBadRequest, existingErr, "ignored %s", "message")
+		assert.Same(t, existingErr, httpErr)
+	})
+
+	t.Run("handles nil error", func(t *testing.T) {
+		httpErr := WrapErrorf(http.StatusBadRequest, nil, "invalid %s", "input")
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.Equal(t, "invalid input", httpErr.Message)
+		assert.Nil(t, errors.Unwrap(httpErr))
+	})
+
+	t.Run("uses default status code", func(t *testing.T) {
+		httpErr := WrapErrorf(0, errors.New("standard error"), "failed")
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+	})
+}
+
+func TestNewWithCause(t *testing.T) {
+	t.Run("wraps cause with separate message", func(t *testing.T) {
+		cause := errors.New("connection refused")
+		httpErr := NewWithCause(http.StatusBadGateway, "request failed", cause)
+		assert.Equal(t, http.StatusBadGateway, httpErr.Code)
+		assert.Equal(t, "request failed", httpErr.Message)
+		assert.Equal(t, cause, errors.Unwrap(httpErr))
+		assert.True(t, errors.Is(httpErr, cause))
+	})
+
+	t.Run("wraps existing HTTPError", func(t *testing.T) {
+		cause := NewHTTPError(http.StatusNotFound, "user not found")
+		httpErr := NewWithCause(http.StatusInternalServerError, "lookup failed", cause)
+		assert.NotSame(t, cause, httpErr)
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		assert.Same(t, cause, errors.Unwrap(httpErr))
+		assert.True(t, errors.Is(fmt.Errorf("handler: %w", httpErr), cause))
+	})
+
+	t.Run("handles nil cause", func(t *testing.T) {
+		httpErr := NewWithCause(http.StatusBadRequest, "invalid input", nil)
+		assert.Nil(t, errors.Unwrap(httpErr))
+	})
+}
+
+func TestUpgradeStatus(t *testing.T) {
+	t.Run("recodes HTTPError", func(t *testing.T) {
+		original := NewHTTPError(http.StatusServiceUnavailable, "dependency unavailable")
+		httpErr := UpgradeStatus(original, http.StatusInternalServerError)
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		assert.Equal(t, "dependency unavailable", httpErr.Message)
+		assert.Same(t, original, errors.Unwrap(httpErr))
+		code, ok := OriginalCode(httpErr)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+	})
+
+	t.Run("recodes plain error", func(t *testing.T) {
+		stdErr := errors.New("standard error")
+		httpErr := UpgradeStatus(stdErr, http.StatusBadGateway)
+		assert.Equal(t, http.StatusBadGateway, httpErr.Code)
+		assert.Equal(t, "standard error", httpErr.Message)
+		assert.True(t, errors.Is(httpErr, stdErr))
+		code, ok := OriginalCode(httpErr)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("category reflects new code", func(t *testing.T) {
+		httpErr := UpgradeStatus(NewHTTPError(http.StatusNotFound, "not found"), http.StatusInternalServerError)
+		assert.True(t, IsServerError(httpErr))
+		assert.False(t, IsClientError(httpErr))
+	})
+
+	t.Run("handles nil error", func(t *testing.T) {
+		assert.Nil(t, UpgradeStatus(nil, http.StatusInternalServerError))
+	})
+}
+
+func TestDowngradeStatus(t *testing.T) {
+	t.Run("recodes HTTPError", func(t *testing.T) {
+		httpErr := DowngradeStatus(NewHTTPError(http.StatusInternalServerError, "invalid upstream input"), http.StatusBadRequest)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.True(t, IsClientError(httpErr))
+		assert.False(t, IsServerError(httpErr))
+		code, ok := OriginalCode(fmt.Errorf("gateway: %w", httpErr))
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("handles nil error", func(t *testing.T) {
+		assert.Nil(t, DowngradeStatus(nil, http.StatusBadRequest))
+	})
+}
+
+func TestOriginalCode(t *testing.T) {
+	t.Run("returns false when not recoded", func(t *testing.T) {
+		_, ok := OriginalCode(NewHTTPError(http.StatusNotFound, "not found"))
+		assert.False(t, ok)
+		_, ok = OriginalCode(errors.New("standard error"))
+		assert.False(t, ok)
+	})
+}
+
+func TestAnnotate(t *testing.T) {
+	t.Run("annotates plain error as 500", func(t *testing.T) {
+		original := errors.New("connection refused")
+		err := Annotate(original, "loading user")
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		assert.Equal(t, "loading user: connection refused", err.Message)
+		assert.Equal(t, original, err.Unwrap())
+	})
+
+	t.Run("preserves HTTPError code and copies meta", func(t *testing.T) {
+		original := NewHTTPError(http.StatusNotFound, "user not found").AddMetaValue("user_id", "123")
+		err := Annotate(original, "loading profile")
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		assert.Equal(t, "loading profile: user not found", err.Message)
+		assert.Equal(t, "123", err.Meta["user_id"])
+
+		err.AddMetaValue("extra", true)
+		assert.NotContains(t, original.Meta, "extra")
+	})
+
+	t.Run("matches original through the chain", func(t *testing.T) {
+		original := errors.New("boom")
+		assert.True(t, errors.Is(Annotate(original, "doing x"), original))
+		assert.True(t, errors.Is(Annotate(ErrNotFound, "doing x"), ErrNotFound))
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, Annotate(nil, "doing x"))
+	})
+}
+
+func TestAnnotatef(t *testing.T) {
+	t.Run("annotates with formatted message", func(t *testing.T) {
+		original := NewHTTPError(http.StatusConflict, "version mismatch")
+		err := Annotatef(original, "updating order %d", 42)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		assert.Equal(t, "updating order 42: version mismatch", err.Message)
+		assert.True(t, errors.Is(err, original))
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, Annotatef(nil, "doing %s", "x"))
+	})
+}
+
 func TestHTTPErrorError(t *testing.T) {
 	t.Run("returns formatted error string", func(t *testing.T) {
 		err := NewHTTPError(http.StatusBadRequest, "bad request")
@@ -55,6 +246,60 @@ func TestHTTPErrorError(t *testing.T) {
 	})
 }
 
+func TestHTTPErrorStatusCode(t *testing.T) {
+	t.Run("returns code", func(t *testing.T) {
+		err := NewHTTPError(http.StatusTooManyRequests, "slow down")
+		assert.Equal(t, http.StatusTooManyRequests, err.StatusCode())
+	})
+
+	t.Run("satisfies StatusCode interface", func(t *testing.T) {
+		var err error = NewHTTPError(http.StatusBadGateway, "bad gateway")
+		sc, ok := err.(interface{ StatusCode() int })
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadGateway, sc.StatusCode())
+	})
+}
+
+func TestHTTPErrorReason(t *testing.T) {
+	t.Run("returns reason phrase for status code", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "user not found")
+		assert.Equal(t, "Not Found", err.Reason())
+	})
+
+	t.Run("returns empty string for unknown status code", func(t *testing.T) {
+		err := NewHTTPError(599, "unknown")
+		assert.Equal(t, "", err.Reason())
+	})
+}
+
+func TestHTTPErrorStatusText(t *testing.T) {
+	t.Run("matches net/http for all named codes", func(t *testing.T) {
+		for code := 100; code < 600; code++ {
+			err := NewHTTPError(code, "message")
+			assert.Equal(t, http.StatusText(code), err.StatusText(), code)
+		}
+	})
+
+	t.Run("returns empty string for unknown code", func(t *testing.T) {
+		assert.Equal(t, "", NewHTTPError(599, "unknown").StatusText())
+	})
+}
+
+func TestStatusText(t *testing.T) {
+	t.Run("returns status text for wrapped HTTPError", func(t *testing.T) {
+		err := fmt.Errorf("handler: %w", NewHTTPError(http.StatusTeapot, "short and stout"))
+		assert.Equal(t, "I'm a teapot", StatusText(err))
+	})
+
+	t.Run("returns Internal Server Error for plain error", func(t *testing.T) {
+		assert.Equal(t, "Internal Server Error", StatusText(errors.New("standard error")))
+	})
+
+	t.Run("returns empty string for nil error", func(t *testing.T) {
+		assert.Equal(t, "", StatusText(nil))
+	})
+}
+
 func TestHTTPErrorAddMetaValue(t *testing.T) {
 	t.Run("adds meta value", func(t *testing.T) {
 		err := NewHTTPError(http.StatusBadRequest, "bad request")
@@ -63,6 +308,42 @@ func TestHTTPErrorAddMetaValue(t *testing.T) {
 	})
 }
 
+func TestHTTPErrorWithCode(t *testing.T) {
+	t.Run("sets code and returns receiver", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "internal")
+		assert.Same(t, err, err.WithCode(http.StatusServiceUnavailable))
+		assert.Equal(t, http.StatusServiceUnavailable, err.Code)
+	})
+}
+
+func TestHTTPErrorWithMessage(t *testing.T) {
+	t.Run("sets message and returns receiver", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "internal")
+		assert.Same(t, err, err.WithMessage("overloaded"))
+		assert.Equal(t, "overloaded", err.Message)
+	})
+}
+
+func TestHTTPErrorWithErr(t *testing.T) {
+	t.Run("sets wrapped error and returns receiver", func(t *testing.T) {
+		cause := errors.New("cause")
+		err := NewHTTPError(http.StatusInternalServerError, "internal")
+		assert.Same(t, err, err.WithErr(cause))
+		assert.Equal(t, cause, err.Unwrap())
+	})
+
+	t.Run("chains with WithCode and WithMessage", func(t *testing.T) {
+		cause := errors.New("cause")
+		err := NewHTTPError(http.StatusInternalServerError, "internal").
+			WithCode(http.StatusServiceUnavailable).
+			WithMessage("overloaded").
+			WithErr(cause)
+		assert.Equal(t, http.StatusServiceUnavailable, err.Code)
+		assert.Equal(t, "overloaded", err.Message)
+		assert.True(t, errors.Is(err, cause))
+	})
+}
+
 func TestGetStatusCode(t *testing.T) {
 	t.Run("returns status code for HTTPError", func(t *testing.T) {
 		err := NewHTTPError(http.StatusNotFound, "not found")
@@ -99,6 +380,42 @@ func TestIsStatus(t *testing.T) {
 	})
 }
 
+func TestIsContinue(t *testing.T) {
+	t.Run("returns true for Continue status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusContinue, "Continue")
+		assert.True(t, IsContinue(err))
+	})
+
+	t.Run("returns false for non-Continue status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsContinue(err))
+	})
+}
+
+func TestIsSwitchingProtocols(t *testing.T) {
+	t.Run("returns true for SwitchingProtocols status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusSwitchingProtocols, "Switching Protocols")
+		assert.True(t, IsSwitchingProtocols(err))
+	})
+
+	t.Run("returns false for non-SwitchingProtocols status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsSwitchingProtocols(err))
+	})
+}
+
+func TestIsEarlyHints(t *testing.T) {
+	t.Run("returns true for EarlyHints status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusEarlyHints, "Early Hints")
+		assert.True(t, IsEarlyHints(err))
+	})
+
+	t.Run("returns false for non-EarlyHints status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsEarlyHints(err))
+	})
+}
+
 // Add test functions for IsOK, IsCreated, IsAccepted, etc.
 func TestIsOK(t *testing.T) {
 	t.Run("returns true for OK status", func(t *testing.T) {
@@ -148,6 +465,138 @@ func TestIsNoContent(t *testing.T) {
 	})
 }
 
+func TestIsPartialContent(t *testing.T) {
+	t.Run("returns true for PartialContent status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusPartialContent, "Partial Content")
+		assert.True(t, IsPartialContent(err))
+	})
+
+	t.Run("returns false for non-PartialContent status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsPartialContent(err))
+	})
+}
+
+func TestIsMultiStatus(t *testing.T) {
+	t.Run("returns true for MultiStatus status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusMultiStatus, "Multi-Status")
+		assert.True(t, IsMultiStatus(err))
+	})
+
+	t.Run("returns false for non-MultiStatus status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsMultiStatus(err))
+	})
+}
+
+func TestIsAlreadyReported(t *testing.T) {
+	t.Run("returns true for AlreadyReported status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusAlreadyReported, "Already Reported")
+		assert.True(t, IsAlreadyReported(err))
+	})
+
+	t.Run("returns false for non-AlreadyReported status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsAlreadyReported(err))
+	})
+}
+
+func TestIsIMUsed(t *testing.T) {
+	t.Run("returns true for IMUsed status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusIMUsed, "IM Used")
+		assert.True(t, IsIMUsed(err))
+	})
+
+	t.Run("returns false for non-IMUsed status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsIMUsed(err))
+	})
+}
+
+func TestIsMultipleChoices(t *testing.T) {
+	t.Run("returns true for MultipleChoices status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusMultipleChoices, "Multiple Choices")
+		assert.True(t, IsMultipleChoices(err))
+	})
+
+	t.Run("returns false for non-MultipleChoices status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsMultipleChoices(err))
+	})
+}
+
+func TestIsMovedPermanently(t *testing.T) {
+	t.Run("returns true for MovedPermanently status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusMovedPermanently, "Moved Permanently")
+		assert.True(t, IsMovedPermanently(err))
+	})
+
+	t.Run("returns false for non-MovedPermanently status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsMovedPermanently(err))
+	})
+}
+
+func TestIsFound(t *testing.T) {
+	t.Run("returns true for Found status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusFound, "Found")
+		assert.True(t, IsFound(err))
+	})
+
+	t.Run("returns false for non-Found status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsFound(err))
+	})
+}
+
+func TestIsSeeOther(t *testing.T) {
+	t.Run("returns true for SeeOther status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusSeeOther, "See Other")
+		assert.True(t, IsSeeOther(err))
+	})
+
+	t.Run("returns false for non-SeeOther status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsSeeOther(err))
+	})
+}
+
+func TestIsNotModified(t *testing.T) {
+	t.Run("returns true for NotModified status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotModified, "Not Modified")
+		assert.True(t, IsNotModified(err))
+	})
+
+	t.Run("returns false for non-NotModified status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsNotModified(err))
+	})
+}
+
+func TestIsTemporaryRedirect(t *testing.T) {
+	t.Run("returns true for TemporaryRedirect status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusTemporaryRedirect, "Temporary Redirect")
+		assert.True(t, IsTemporaryRedirect(err))
+	})
+
+	t.Run("returns false for non-TemporaryRedirect status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsTemporaryRedirect(err))
+	})
+}
+
+func TestIsPermanentRedirect(t *testing.T) {
+	t.Run("returns true for PermanentRedirect status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusPermanentRedirect, "Permanent Redirect")
+		assert.True(t, IsPermanentRedirect(err))
+	})
+
+	t.Run("returns false for non-PermanentRedirect status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsPermanentRedirect(err))
+	})
+}
+
 func TestIsBadRequest(t *testing.T) {
 	t.Run("returns true for BadRequest status", func(t *testing.T) {
 		err := NewHTTPError(http.StatusBadRequest, "Bad Request")
@@ -172,6 +621,19 @@ func TestIsUnauthorized(t *testing.T) {
 	})
 }
 
+func TestIsPaymentRequired(t *testing.T) {
+	t.Run("returns true for PaymentRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusPaymentRequired, "Payment Required")
+		assert.True(t, IsPaymentRequired(err))
+	})
+
+	t.Run("returns false for non-PaymentRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsPaymentRequired(err))
+		assert.False(t, IsPaymentRequired(errors.New("standard error")))
+	})
+}
+
 func TestIsForbidden(t *testing.T) {
 	t.Run("returns true for Forbidden status", func(t *testing.T) {
 		err := NewHTTPError(http.StatusForbidden, "Forbidden")
@@ -196,6 +658,303 @@ func TestIsNotFound(t *testing.T) {
 	})
 }
 
+func TestIsMethodNotAllowed(t *testing.T) {
+	t.Run("returns true for MethodNotAllowed status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusMethodNotAllowed, "Method Not Allowed")
+		assert.True(t, IsMethodNotAllowed(err))
+	})
+
+	t.Run("returns false for non-MethodNotAllowed status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsMethodNotAllowed(err))
+	})
+}
+
+func TestIsNotAcceptable(t *testing.T) {
+	t.Run("returns true for NotAcceptable status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotAcceptable, "Not Acceptable")
+		assert.True(t, IsNotAcceptable(err))
+	})
+
+	t.Run("returns false for non-NotAcceptable status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsNotAcceptable(err))
+	})
+}
+
+func TestIsProxyAuthRequired(t *testing.T) {
+	t.Run("returns true for ProxyAuthRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusProxyAuthRequired, "Proxy Authentication Required")
+		assert.True(t, IsProxyAuthRequired(err))
+	})
+
+	t.Run("returns false for non-ProxyAuthRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsProxyAuthRequired(err))
+	})
+}
+
+func TestIsRequestTimeout(t *testing.T) {
+	t.Run("returns true for RequestTimeout status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusRequestTimeout, "Request Timeout")
+		assert.True(t, IsRequestTimeout(err))
+	})
+
+	t.Run("returns false for non-RequestTimeout status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsRequestTimeout(err))
+	})
+}
+
+func TestIsConflict(t *testing.T) {
+	t.Run("returns true for Conflict status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusConflict, "Conflict")
+		assert.True(t, IsConflict(err))
+	})
+
+	t.Run("returns false for non-Conflict status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsConflict(err))
+	})
+}
+
+func TestIsGone(t *testing.T) {
+	t.Run("returns true for Gone status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusGone, "Gone")
+		assert.True(t, IsGone(err))
+	})
+
+	t.Run("returns false for non-Gone status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsGone(err))
+	})
+}
+
+func TestIsLengthRequired(t *testing.T) {
+	t.Run("returns true for LengthRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusLengthRequired, "Length Required")
+		assert.True(t, IsLengthRequired(err))
+	})
+
+	t.Run("returns false for non-LengthRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsLengthRequired(err))
+		assert.False(t, IsLengthRequired(errors.New("standard error")))
+	})
+}
+
+func TestIsPreconditionFailed(t *testing.T) {
+	t.Run("returns true for PreconditionFailed status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusPreconditionFailed, "Precondition Failed")
+		assert.True(t, IsPreconditionFailed(err))
+	})
+
+	t.Run("returns false for non-PreconditionFailed status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsPreconditionFailed(err))
+		assert.False(t, IsPreconditionFailed(errors.New("standard error")))
+	})
+}
+
+func TestIsPayloadTooLarge(t *testing.T) {
+	t.Run("returns true for PayloadTooLarge status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusRequestEntityTooLarge, "Request Entity Too Large")
+		assert.True(t, IsPayloadTooLarge(err))
+	})
+
+	t.Run("returns false for non-PayloadTooLarge status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsPayloadTooLarge(err))
+	})
+}
+
+func TestIsURITooLong(t *testing.T) {
+	t.Run("returns true for URITooLong status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusRequestURITooLong, "Request URI Too Long")
+		assert.True(t, IsURITooLong(err))
+	})
+
+	t.Run("returns false for non-URITooLong status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsURITooLong(err))
+	})
+}
+
+func TestIsUnsupportedMediaType(t *testing.T) {
+	t.Run("returns true for UnsupportedMediaType status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnsupportedMediaType, "Unsupported Media Type")
+		assert.True(t, IsUnsupportedMediaType(err))
+	})
+
+	t.Run("returns false for non-UnsupportedMediaType status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsUnsupportedMediaType(err))
+	})
+}
+
+func TestIsRangeNotSatisfiable(t *testing.T) {
+	t.Run("returns true for RangeNotSatisfiable status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusRequestedRangeNotSatisfiable, "Requested Range Not Satisfiable")
+		assert.True(t, IsRangeNotSatisfiable(err))
+	})
+
+	t.Run("returns false for non-RangeNotSatisfiable status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsRangeNotSatisfiable(err))
+	})
+}
+
+func TestIsExpectationFailed(t *testing.T) {
+	t.Run("returns true for ExpectationFailed status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusExpectationFailed, "Expectation Failed")
+		assert.True(t, IsExpectationFailed(err))
+	})
+
+	t.Run("returns false for non-ExpectationFailed status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsExpectationFailed(err))
+	})
+}
+
+func TestIsTeapot(t *testing.T) {
+	t.Run("returns true for Teapot status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusTeapot, "I'm a teapot")
+		assert.True(t, IsTeapot(err))
+	})
+
+	t.Run("returns false for non-Teapot status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsTeapot(err))
+	})
+}
+
+func TestIsMisdirectedRequest(t *testing.T) {
+	t.Run("returns true for MisdirectedRequest status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusMisdirectedRequest, "Misdirected Request")
+		assert.True(t, IsMisdirectedRequest(err))
+	})
+
+	t.Run("returns false for non-MisdirectedRequest status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsMisdirectedRequest(err))
+	})
+}
+
+func TestIsUnprocessableEntity(t *testing.T) {
+	t.Run("returns true for UnprocessableEntity status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnprocessableEntity, "Unprocessable Entity")
+		assert.True(t, IsUnprocessableEntity(err))
+	})
+
+	t.Run("returns false for non-UnprocessableEntity status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsUnprocessableEntity(err))
+	})
+}
+
+func TestIsLocked(t *testing.T) {
+	t.Run("returns true for Locked status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusLocked, "Locked")
+		assert.True(t, IsLocked(err))
+	})
+
+	t.Run("returns false for non-Locked status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsLocked(err))
+		assert.False(t, IsLocked(errors.New("standard error")))
+	})
+}
+
+func TestIsFailedDependency(t *testing.T) {
+	t.Run("returns true for FailedDependency status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusFailedDependency, "Failed Dependency")
+		assert.True(t, IsFailedDependency(err))
+	})
+
+	t.Run("returns false for non-FailedDependency status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsFailedDependency(err))
+		assert.False(t, IsFailedDependency(errors.New("standard error")))
+	})
+}
+
+func TestIsTooEarly(t *testing.T) {
+	t.Run("returns true for TooEarly status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusTooEarly, "Too Early")
+		assert.True(t, IsTooEarly(err))
+	})
+
+	t.Run("returns false for non-TooEarly status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsTooEarly(err))
+		assert.False(t, IsTooEarly(errors.New("standard error")))
+	})
+}
+
+func TestIsUpgradeRequired(t *testing.T) {
+	t.Run("returns true for UpgradeRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUpgradeRequired, "Upgrade Required")
+		assert.True(t, IsUpgradeRequired(err))
+	})
+
+	t.Run("returns false for non-UpgradeRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsUpgradeRequired(err))
+		assert.False(t, IsUpgradeRequired(errors.New("standard error")))
+	})
+}
+
+func TestIsPreconditionRequired(t *testing.T) {
+	t.Run("returns true for PreconditionRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusPreconditionRequired, "Precondition Required")
+		assert.True(t, IsPreconditionRequired(err))
+	})
+
+	t.Run("returns false for non-PreconditionRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsPreconditionRequired(err))
+		assert.False(t, IsPreconditionRequired(errors.New("standard error")))
+	})
+}
+
+func TestIsTooManyRequests(t *testing.T) {
+	t.Run("returns true for TooManyRequests status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusTooManyRequests, "Too Many Requests")
+		assert.True(t, IsTooManyRequests(err))
+	})
+
+	t.Run("returns false for non-TooManyRequests status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsTooManyRequests(err))
+	})
+}
+
+func TestIsRequestHeaderFieldsTooLarge(t *testing.T) {
+	t.Run("returns true for RequestHeaderFieldsTooLarge status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusRequestHeaderFieldsTooLarge, "Request Header Fields Too Large")
+		assert.True(t, IsRequestHeaderFieldsTooLarge(err))
+	})
+
+	t.Run("returns false for non-RequestHeaderFieldsTooLarge status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsRequestHeaderFieldsTooLarge(err))
+		assert.False(t, IsRequestHeaderFieldsTooLarge(errors.New("standard error")))
+	})
+}
+
+func TestIsUnavailableForLegalReasons(t *testing.T) {
+	t.Run("returns true for UnavailableForLegalReasons status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnavailableForLegalReasons, "Unavailable For Legal Reasons")
+		assert.True(t, IsUnavailableForLegalReasons(err))
+	})
+
+	t.Run("returns false for non-UnavailableForLegalReasons status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsUnavailableForLegalReasons(err))
+		assert.False(t, IsUnavailableForLegalReasons(errors.New("standard error")))
+	})
+}
+
 func TestIsInternalServerError(t *testing.T) {
 	t.Run("returns true for InternalServerError status", func(t *testing.T) {
 		err := NewHTTPError(http.StatusInternalServerError, "Internal Server Error")
@@ -208,6 +967,18 @@ func TestIsInternalServerError(t *testing.T) {
 	})
 }
 
+func TestIsNotImplemented(t *testing.T) {
+	t.Run("returns true for NotImplemented status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotImplemented, "Not Implemented")
+		assert.True(t, IsNotImplemented(err))
+	})
+
+	t.Run("returns false for non-NotImplemented status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsNotImplemented(err))
+	})
+}
+
 func TestIsBadGateway(t *testing.T) {
 	t.Run("returns true for BadGateway status", func(t *testing.T) {
 		err := NewHTTPError(http.StatusBadGateway, "Bad Gateway")
@@ -244,6 +1015,78 @@ func TestIsGatewayTimeout(t *testing.T) {
 	})
 }
 
+func TestIsHTTPVersionNotSupported(t *testing.T) {
+	t.Run("returns true for HTTPVersionNotSupported status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusHTTPVersionNotSupported, "HTTP Version Not Supported")
+		assert.True(t, IsHTTPVersionNotSupported(err))
+	})
+
+	t.Run("returns false for non-HTTPVersionNotSupported status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsHTTPVersionNotSupported(err))
+	})
+}
+
+func TestIsVariantAlsoNegotiates(t *testing.T) {
+	t.Run("returns true for VariantAlsoNegotiates status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusVariantAlsoNegotiates, "Variant Also Negotiates")
+		assert.True(t, IsVariantAlsoNegotiates(err))
+	})
+
+	t.Run("returns false for non-VariantAlsoNegotiates status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsVariantAlsoNegotiates(err))
+	})
+}
+
+func TestIsInsufficientStorage(t *testing.T) {
+	t.Run("returns true for InsufficientStorage status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInsufficientStorage, "Insufficient Storage")
+		assert.True(t, IsInsufficientStorage(err))
+	})
+
+	t.Run("returns false for non-InsufficientStorage status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsInsufficientStorage(err))
+	})
+}
+
+func TestIsLoopDetected(t *testing.T) {
+	t.Run("returns true for LoopDetected status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusLoopDetected, "Loop Detected")
+		assert.True(t, IsLoopDetected(err))
+	})
+
+	t.Run("returns false for non-LoopDetected status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsLoopDetected(err))
+	})
+}
+
+func TestIsNotExtended(t *testing.T) {
+	t.Run("returns true for NotExtended status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotExtended, "Not Extended")
+		assert.True(t, IsNotExtended(err))
+	})
+
+	t.Run("returns false for non-NotExtended status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsNotExtended(err))
+	})
+}
+
+func TestIsNetworkAuthenticationRequired(t *testing.T) {
+	t.Run("returns true for NetworkAuthenticationRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNetworkAuthenticationRequired, "Network Authentication Required")
+		assert.True(t, IsNetworkAuthenticationRequired(err))
+	})
+
+	t.Run("returns false for non-NetworkAuthenticationRequired status", func(t *testing.T) {
+		err := NewHTTPError(http.StatusOK, "OK")
+		assert.False(t, IsNetworkAuthenticationRequired(err))
+	})
+}
+
 func TestIsError(t *testing.T) {
 	t.Run("returns true for error status codes", func(t *testing.T) {
 		errorCodes := []int{http.StatusBadRequest, http.StatusInternalServerError, http.StatusNotImplemented}
@@ -346,3 +1189,204 @@ func TestIsServerError(t *testing.T) {
 		}
 	})
 }
+
+func TestWrapIfChanged(t *testing.T) {
+	t.Run("returns existing error when code matches", func(t *testing.T) {
+		existingErr := NewHTTPError(http.StatusNotFound, "not found")
+		httpErr := WrapIfChanged(existingErr, http.StatusNotFound, "missing")
+		assert.Same(t, existingErr, httpErr)
+	})
+
+	t.Run("returns wrapped HTTPError when code matches", func(t *testing.T) {
+		existingErr := NewHTTPError(http.StatusNotFound, "not found")
+		httpErr := WrapIfChanged(fmt.Errorf("loading user: %w", existingErr), http.StatusNotFound, "missing")
+		assert.Same(t, existingErr, httpErr)
+	})
+
+	t.Run("wraps error when code differs", func(t *testing.T) {
+		existingErr := NewHTTPError(http.StatusServiceUnavailable, "unavailable")
+		httpErr := WrapIfChanged(existingErr, http.StatusInternalServerError, "internal error")
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		assert.Equal(t, "internal error", httpErr.Message)
+		assert.Equal(t, existingErr, errors.Unwrap(httpErr))
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		stdErr := errors.New("standard error")
+		first := WrapIfChanged(stdErr, http.StatusBadRequest, "bad request")
+		second := WrapIfChanged(first, http.StatusBadRequest, "bad request")
+		assert.Same(t, first, second)
+	})
+
+	t.Run("uses original message when new message is empty", func(t *testing.T) {
+		stdErr := errors.New("standard error")
+		httpErr := WrapIfChanged(stdErr, http.StatusBadRequest, "")
+		assert.Equal(t, "standard error", httpErr.Message)
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, WrapIfChanged(nil, http.StatusBadRequest, "bad request"))
+	})
+}
+
+func TestMustHTTPError(t *testing.T) {
+	t.Run("returns HTTPError from chain", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found")
+		assert.Same(t, err, MustHTTPError(fmt.Errorf("wrapped: %w", err)))
+	})
+
+	t.Run("panics for non-HTTPError", func(t *testing.T) {
+		assert.PanicsWithValue(t, "expected *HTTPError but got *errors.errorString: standard error", func() {
+			MustHTTPError(errors.New("standard error"))
+		})
+	})
+
+	t.Run("panics for nil error", func(t *testing.T) {
+		assert.PanicsWithValue(t, "expected *HTTPError but got <nil>: <nil>", func() {
+			MustHTTPError(nil)
+		})
+	})
+}
+
+func TestIsInRange(t *testing.T) {
+	t.Run("includes low and excludes high", func(t *testing.T) {
+		assert.True(t, IsInRange(NewHTTPError(http.StatusBadRequest, "bad request"), 400, 500))
+		assert.True(t, IsInRange(NewHTTPError(499, "client closed request"), 400, 500))
+		assert.False(t, IsInRange(NewHTTPError(http.StatusInternalServerError, "internal"), 400, 500))
+		assert.False(t, IsInRange(NewHTTPError(http.StatusPermanentRedirect, "redirect"), 400, 500))
+	})
+
+	t.Run("works through wrapped errors", func(t *testing.T) {
+		err := fmt.Errorf("handler: %w", NewHTTPError(http.StatusConflict, "conflict"))
+		assert.True(t, IsInRange(err, 400, 500))
+	})
+
+	t.Run("returns false for nil, plain errors and invalid codes", func(t *testing.T) {
+		assert.False(t, IsInRange(nil, 0, 1000))
+		assert.False(t, IsInRange(errors.New("plain"), 0, 1000))
+		assert.False(t, IsInRange(NewHTTPError(99, "too low"), 0, 1000))
+		assert.False(t, IsInRange(NewHTTPError(600, "too high"), 0, 1000))
+	})
+}
+
+func TestIsInClosedRange(t *testing.T) {
+	t.Run("includes low and high", func(t *testing.T) {
+		assert.True(t, IsInClosedRange(NewHTTPError(http.StatusBadRequest, "bad request"), 400, 499))
+		assert.True(t, IsInClosedRange(NewHTTPError(499, "client closed request"), 400, 499))
+		assert.False(t, IsInClosedRange(NewHTTPError(http.StatusInternalServerError, "internal"), 400, 499))
+		assert.False(t, IsInClosedRange(NewHTTPError(http.StatusPermanentRedirect, "redirect"), 400, 499))
+	})
+
+	t.Run("returns false for nil, plain errors and invalid codes", func(t *testing.T) {
+		assert.False(t, IsInClosedRange(nil, 0, 1000))
+		assert.False(t, IsInClosedRange(errors.New("plain"), 0, 1000))
+		assert.False(t, IsInClosedRange(NewHTTPError(600, "too high"), 0, 1000))
+	})
+}
+
+func TestIsAboveCode(t *testing.T) {
+	t.Run("compares status code", func(t *testing.T) {
+		assert.True(t, IsAboveCode(NewHTTPError(http.StatusBadGateway, "bad gateway"), 500))
+		assert.False(t, IsAboveCode(NewHTTPError(http.StatusInternalServerError, "internal"), 500))
+	})
+
+	t.Run("returns false for nil, plain errors and invalid codes", func(t *testing.T) {
+		assert.False(t, IsAboveCode(nil, 0))
+		assert.False(t, IsAboveCode(errors.New("plain"), 0))
+		assert.False(t, IsAboveCode(NewHTTPError(600, "too high"), 500))
+	})
+}
+
+func TestIsBelowCode(t *testing.T) {
+	t.Run("compares status code", func(t *testing.T) {
+		assert.True(t, IsBelowCode(NewHTTPError(http.StatusNotFound, "not found"), 500))
+		assert.False(t, IsBelowCode(NewHTTPError(http.StatusInternalServerError, "internal"), 500))
+	})
+
+	t.Run("returns false for nil, plain errors and invalid codes", func(t *testing.T) {
+		assert.False(t, IsBelowCode(nil, 1000))
+		assert.False(t, IsBelowCode(errors.New("plain"), 1000))
+		assert.False(t, IsBelowCode(NewHTTPError(0, "no code"), 500))
+	})
+}
+
+func TestIsNilOrSuccess(t *testing.T) {
+	t.Run("returns true for nil errors", func(t *testing.T) {
+		var typedNil *HTTPError
+		assert.True(t, IsNilOrSuccess(nil))
+		assert.True(t, IsNilOrSuccess(typedNil))
+	})
+
+	t.Run("returns true for 2XX errors", func(t *testing.T) {
+		assert.True(t, IsNilOrSuccess(NewHTTPError(http.StatusOK, "OK")))
+		assert.True(t, IsNilOrSuccess(fmt.Errorf("wrapped: %w", NewHTTPError(http.StatusCreated, "Created"))))
+	})
+
+	t.Run("returns false for other errors", func(t *testing.T) {
+		assert.False(t, IsNilOrSuccess(NewHTTPError(http.StatusBadRequest, "Bad Request")))
+		assert.False(t, IsNilOrSuccess(errors.New("plain")))
+	})
+}
+
+func TestMustBeSuccess(t *testing.T) {
+	t.Run("does not panic for nil or 2XX errors", func(t *testing.T) {
+		var typedNil *HTTPError
+		assert.NotPanics(t, func() { MustBeSuccess(nil) })
+		assert.NotPanics(t, func() { MustBeSuccess(typedNil) })
+		assert.NotPanics(t, func() { MustBeSuccess(NewHTTPError(http.StatusOK, "OK")) })
+		assert.NotPanics(t, func() { MustBeSuccess(NewHTTPError(http.StatusCreated, "Created")) })
+	})
+
+	t.Run("panics with HTTPError for 4XX errors", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "Bad Request")
+		assert.PanicsWithValue(t, err, func() { MustBeSuccess(err) })
+	})
+
+	t.Run("panics with HTTPError for plain errors", func(t *testing.T) {
+		recovered := RecoverHTTPError(func() { MustBeSuccess(errors.New("boom")) })
+		assert.Equal(t, http.StatusInternalServerError, recovered.Code)
+		assert.Equal(t, "boom", recovered.Message)
+	})
+}
+
+func TestAsHTTPError(t *testing.T) {
+	t.Run("returns direct HTTPError", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found")
+		httpErr, ok := AsHTTPError(err)
+		assert.True(t, ok)
+		assert.Same(t, err, httpErr)
+	})
+
+	t.Run("returns wrapped HTTPError", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found")
+		httpErr, ok := AsHTTPError(fmt.Errorf("handler: %w", err))
+		assert.True(t, ok)
+		assert.Same(t, err, httpErr)
+	})
+
+	t.Run("returns false for plain error", func(t *testing.T) {
+		httpErr, ok := AsHTTPError(errors.New("plain"))
+		assert.False(t, ok)
+		assert.Nil(t, httpErr)
+	})
+
+	t.Run("returns false for nil", func(t *testing.T) {
+		var typedNil *HTTPError
+		_, ok := AsHTTPError(nil)
+		assert.False(t, ok)
+		_, ok = AsHTTPError(typedNil)
+		assert.False(t, ok)
+	})
+}
+
+func TestExtractHTTPError(t *testing.T) {
+	t.Run("behaves like AsHTTPError", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found")
+		httpErr, ok := ExtractHTTPError(fmt.Errorf("handler: %w", err))
+		assert.True(t, ok)
+		assert.Same(t, err, httpErr)
+
+		_, ok = ExtractHTTPError(errors.New("plain"))
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,94 @@
+package httperror
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+)
+
+// ImmutableHTTPError is a read-only snapshot of an HTTPError that can be shared
+// safely between goroutines. Create one with HTTPError.Freeze.
+type ImmutableHTTPError struct {
+	e *HTTPError
+}
+
+// Freeze returns a read-only snapshot of the HTTPError. The snapshot has its own
+// Meta map and Header, and its own copies of the Meta values the package stores
+// itself, such as validation errors, response headers and allowed methods, so
+// later changes to the HTTPError do not affect it.
+func (e *HTTPError) Freeze() *ImmutableHTTPError {
+	return &ImmutableHTTPError{e: e.deepClone()}
+}
+
+// deepClone returns a clone of the HTTPError that also copies the Meta values
+// of the types the package stores itself.
+func (e *HTTPError) deepClone() *HTTPError {
+	clone := e.Clone()
+	for k, v := range clone.Meta {
+		clone.Meta[k] = copyMetaValue(v)
+	}
+	return clone
+}
+
+// copyMetaValue returns a copy of v if it is a map[string]string, http.Header or
+// []string, and v itself otherwise.
+func copyMetaValue(v any) any {
+	switch v := v.(type) {
+	case map[string]string:
+		return maps.Clone(v)
+	case http.Header:
+		return v.Clone()
+	case []string:
+		return slices.Clone(v)
+	}
+	return v
+}
+
+// Thaw returns a mutable clone of the snapshot.
+func (i *ImmutableHTTPError) Thaw() *HTTPError {
+	return i.e.deepClone()
+}
+
+// Code returns the HTTP status code.
+func (i *ImmutableHTTPError) Code() int {
+	return i.e.Code
+}
+
+// Message returns the error message.
+func (i *ImmutableHTTPError) Message() string {
+	return i.e.Message
+}
+
+// MetaCopy returns a copy of the metadata.
+func (i *ImmutableHTTPError) MetaCopy() map[string]any {
+	meta := make(map[string]any, len(i.e.Meta))
+	for k, v := range i.e.Meta {
+		meta[k] = copyMetaValue(v)
+	}
+	return meta
+}
+
+// Error returns the error message as a string, like HTTPError.Error.
+func (i *ImmutableHTTPError) Error() string {
+	return i.e.Error()
+}
+
+// Unwrap returns the error wrapped by the snapshot.
+func (i *ImmutableHTTPError) Unwrap() error {
+	return i.e.err
+}
+
+// Is reports whether target matches the snapshot, like HTTPError.Is.
+func (i *ImmutableHTTPError) Is(target error) bool {
+	return i.e.Is(target)
+}
+
+// As sets target to a mutable clone of the snapshot when target is a **HTTPError,
+// so that errors.As finds frozen errors without exposing the snapshot itself.
+func (i *ImmutableHTTPError) As(target any) bool {
+	if t, ok := target.(**HTTPError); ok {
+		*t = i.Thaw()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorFreeze(t *testing.T) {
+	t.Run("snapshots the error", func(t *testing.T) {
+		cause := errors.New("cause")
+		err := WrapErrorf(http.StatusNotFound, cause, "not found").AddMetaValue("id", "1")
+		frozen := err.Freeze()
+
+		err.AddMetaValue("id", "2")
+		err.Code = http.StatusGone
+
+		assert.Equal(t, http.StatusNotFound, frozen.Code())
+		assert.Equal(t, "not found", frozen.Message())
+		assert.Equal(t, map[string]any{"id": "1"}, frozen.MetaCopy())
+		assert.Equal(t, "[404] HTTP Error: - not found", frozen.Error())
+		assert.Equal(t, cause, frozen.Unwrap())
+	})
+
+	t.Run("MetaCopy returns a copy", func(t *testing.T) {
+		frozen := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "1").Freeze()
+		frozen.MetaCopy()["id"] = "2"
+		assert.Equal(t, "1", frozen.MetaCopy()["id"])
+	})
+
+	t.Run("copies nested meta stored by package helpers", func(t *testing.T) {
+		fieldErrors := map[string]string{"email": "required"}
+		err := NewBatchValidationError(fieldErrors).
+			WithResponseHeaders(http.Header{"Retry-After": {"30"}}).
+			AddMetaValue(AllowedMethodsMetaKey, []string{http.MethodGet})
+		frozen := err.Freeze()
+
+		fieldErrors["email"] = "changed"
+		err.Meta[ValidationErrorsMetaKey].(map[string]string)["name"] = "required"
+		ResponseHeaders(err).Set("Retry-After", "60")
+		err.Meta[AllowedMethodsMetaKey].([]string)[0] = http.MethodPost
+
+		meta := frozen.MetaCopy()
+		assert.Equal(t, map[string]string{"email": "required"}, meta[ValidationErrorsMetaKey])
+		assert.Equal(t, "30", meta[ResponseHeadersMetaKey].(http.Header).Get("Retry-After"))
+		assert.Equal(t, []string{http.MethodGet}, meta[AllowedMethodsMetaKey])
+
+		meta[AllowedMethodsMetaKey].([]string)[0] = http.MethodDelete
+		frozen.Thaw().Meta[AllowedMethodsMetaKey].([]string)[0] = http.MethodPut
+		assert.Equal(t, []string{http.MethodGet}, frozen.MetaCopy()[AllowedMethodsMetaKey])
+	})
+
+	t.Run("is safe for concurrent reads", func(t *testing.T) {
+		frozen := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "1").Freeze()
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = frozen.MetaCopy()
+				_ = frozen.Error()
+				frozen.Thaw().AddMetaValue("extra", true)
+			}()
+		}
+		wg.Wait()
+		assert.NotContains(t, frozen.MetaCopy(), "extra")
+	})
+}
+
+func TestImmutableHTTPErrorThaw(t *testing.T) {
+	t.Run("returns mutable clone", func(t *testing.T) {
+		frozen := NewHTTPError(http.StatusNotFound, "not found").Freeze()
+		thawed := frozen.Thaw().WithCode(http.StatusGone).AddMetaValue("id", "1")
+		assert.Equal(t, http.StatusGone, thawed.Code)
+		assert.Equal(t, http.StatusNotFound, frozen.Code())
+		assert.Empty(t, frozen.MetaCopy())
+	})
+}
+
+func TestImmutableHTTPErrorIs(t *testing.T) {
+	t.Run("matches sentinels", func(t *testing.T) {
+		frozen := NewHTTPError(http.StatusNotFound, "not found").Freeze()
+		assert.True(t, errors.Is(frozen, ErrNotFound))
+		assert.False(t, errors.Is(frozen, ErrGone))
+	})
+}
+
+func TestImmutableHTTPErrorAs(t *testing.T) {
+	t.Run("passes errors.As", func(t *testing.T) {
+		frozen := NewHTTPError(http.StatusNotFound, "not found").Freeze()
+		var httpErr *HTTPError
+		assert.True(t, errors.As(fmt.Errorf("handler: %w", frozen), &httpErr))
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+
+		httpErr.AddMetaValue("id", "1")
+		assert.Empty(t, frozen.MetaCopy())
+	})
+
+	t.Run("finds the snapshot itself", func(t *testing.T) {
+		frozen := NewHTTPError(http.StatusNotFound, "not found").Freeze()
+		var target *ImmutableHTTPError
+		assert.True(t, errors.As(frozen, &target))
+		assert.Same(t, frozen, target)
+	})
+
+	t.Run("works with package helpers", func(t *testing.T) {
+		frozen := NewHTTPError(http.StatusNotFound, "not found").Freeze()
+		assert.True(t, IsNotFound(frozen))
+	})
+}
@@ -0,0 +1,157 @@
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// jsonHTTPError is the wire representation of an HTTPError.
+type jsonHTTPError struct {
+	Code             int               `json:"code"`
+	Message          string            `json:"message"`
+	Meta             map[string]any    `json:"meta"`
+	ValidationErrors map[string]string `json:"validation_errors,omitempty"`
+	Cause            *jsonHTTPError    `json:"cause,omitempty"`
+	SchemaVersion    string            `json:"schema_version,omitempty"`
+}
+
+var (
+	schemaVersionMu   sync.RWMutex
+	jsonSchemaVersion = "1"
+)
+
+// SetJSONSchemaVersion sets the version emitted as "schema_version" in the JSON
+// representation of every HTTPError. The default is "1"; an empty version omits the field.
+func SetJSONSchemaVersion(version string) {
+	schemaVersionMu.Lock()
+	defer schemaVersionMu.Unlock()
+	jsonSchemaVersion = version
+}
+
+// currentJSONSchemaVersion returns the version set by SetJSONSchemaVersion.
+func currentJSONSchemaVersion() string {
+	schemaVersionMu.RLock()
+	defer schemaVersionMu.RUnlock()
+	return jsonSchemaVersion
+}
+
+// GetJSONSchemaVersion returns the schema version of the JSON the HTTPError in
+// err's chain was decoded from, or an empty string if it was not decoded from JSON
+// or the JSON had no version.
+func GetJSONSchemaVersion(err error) string {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return ""
+	}
+	return httpErr.schemaVersion
+}
+
+// includeChainInJSON controls whether MarshalJSON includes the error chain.
+var includeChainInJSON atomic.Bool
+
+// SetIncludeChainInJSON sets whether MarshalJSON produces the chained form of
+// MarshalJSONWithChain instead of the flat form.
+func SetIncludeChainInJSON(enabled bool) {
+	includeChainInJSON.Store(enabled)
+}
+
+// NewHTTPErrorFromJSON creates a new HTTPError from its JSON representation.
+func NewHTTPErrorFromJSON(data []byte) (*HTTPError, error) {
+	e := &HTTPError{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// FromJSONReader decodes an HTTPError from the JSON read from r.
+// If r implements io.Closer, it is closed once decoding is done.
+func FromJSONReader(r io.Reader) (*HTTPError, error) {
+	if c, ok := r.(io.Closer); ok {
+		defer c.Close()
+	}
+	e := &HTTPError{}
+	if err := json.NewDecoder(r).Decode(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// MustFromJSONReader is like FromJSONReader but panics if decoding fails.
+func MustFromJSONReader(r io.Reader) *HTTPError {
+	e, err := FromJSONReader(r)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// MarshalJSON encodes the HTTPError as {"code":...,"message":...,"meta":{...},"schema_version":"1"}.
+// Validation errors are promoted to a top-level "validation_errors" field.
+// The wrapped error is not serialized unless SetIncludeChainInJSON is enabled.
+func (e *HTTPError) MarshalJSON() ([]byte, error) {
+	return e.marshalJSON(includeChainInJSON.Load())
+}
+
+// MarshalJSONWithChain encodes the HTTPError like MarshalJSON, adding the next
+// HTTPError in its chain as a nested "cause" field, recursively.
+func (e *HTTPError) MarshalJSONWithChain() ([]byte, error) {
+	return e.marshalJSON(true)
+}
+
+// marshalJSON encodes the HTTPError with the current schema version.
+func (e *HTTPError) marshalJSON(chain bool) ([]byte, error) {
+	v := e.toJSON(chain)
+	v.SchemaVersion = currentJSONSchemaVersion()
+	return json.Marshal(v)
+}
+
+// toJSON returns the wire representation of the HTTPError, including its
+// chain of wrapped HTTPErrors as causes if chain is true.
+func (e *HTTPError) toJSON(chain bool) *jsonHTTPError {
+	v := &jsonHTTPError{Code: e.Code, Message: e.Message, Meta: encodableMeta(e.Meta)}
+	if fieldErrors := GetValidationErrors(e); fieldErrors != nil {
+		v.ValidationErrors = fieldErrors
+		delete(v.Meta, ValidationErrorsMetaKey)
+	}
+	var cause *HTTPError
+	if chain && errors.As(e.err, &cause) {
+		v.Cause = cause.toJSON(true)
+	}
+	return v
+}
+
+// UnmarshalJSON decodes the HTTPError from its JSON representation.
+// The decoded HTTPError does not wrap an error.
+func (e *HTTPError) UnmarshalJSON(data []byte) error {
+	var v jsonHTTPError
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Meta == nil {
+		v.Meta = make(map[string]any)
+	}
+	if v.ValidationErrors != nil {
+		v.Meta[ValidationErrorsMetaKey] = v.ValidationErrors
+	}
+	e.Code = v.Code
+	e.Message = v.Message
+	e.Meta = v.Meta
+	e.err = nil
+	e.schemaVersion = v.SchemaVersion
+	return nil
+}
+
+// Len returns the length in bytes of the HTTPError's JSON representation.
+// The length is not memoized, since the HTTPError's exported fields may change
+// between calls.
+func (e *HTTPError) Len() (int, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
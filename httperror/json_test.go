@@ -0,0 +1,229 @@
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorMarshalJSON(t *testing.T) {
+	t.Run("uses lowercase field names", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("user_id", "123")
+		data, marshalErr := json.Marshal(err)
+		assert.NoError(t, marshalErr)
+		assert.JSONEq(t, `{"code":404,"message":"not found","meta":{"user_id":"123"},"schema_version":"1"}`, string(data))
+	})
+
+	t.Run("serializes empty meta as object", func(t *testing.T) {
+		data, marshalErr := json.Marshal(NewHTTPError(http.StatusBadRequest, "bad request"))
+		assert.NoError(t, marshalErr)
+		assert.Equal(t, `{"code":400,"message":"bad request","meta":{},"schema_version":"1"}`, string(data))
+	})
+
+	t.Run("serializes nil meta as object", func(t *testing.T) {
+		data, marshalErr := json.Marshal(&HTTPError{Code: http.StatusBadRequest, Message: "bad request"})
+		assert.NoError(t, marshalErr)
+		assert.Equal(t, `{"code":400,"message":"bad request","meta":{},"schema_version":"1"}`, string(data))
+	})
+}
+
+func TestHTTPErrorUnmarshalJSON(t *testing.T) {
+	t.Run("round-trips public fields", func(t *testing.T) {
+		original := WrapError(http.StatusConflict, errors.New("duplicate key")).
+			AddMetaValue("user_id", "123").
+			AddMetaValue("attempt", float64(2)).
+			AddMetaValue("tags", []any{"a", "b"})
+		data, marshalErr := json.Marshal(original)
+		assert.NoError(t, marshalErr)
+
+		var decoded HTTPError
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, original.Code, decoded.Code)
+		assert.Equal(t, original.Message, decoded.Message)
+		assert.Equal(t, original.Meta, decoded.Meta)
+		assert.Nil(t, decoded.Unwrap())
+	})
+
+	t.Run("ignores unknown fields", func(t *testing.T) {
+		var decoded HTTPError
+		assert.NotPanics(t, func() {
+			assert.NoError(t, json.Unmarshal([]byte(`{"code":400,"message":"bad","extra":true}`), &decoded))
+		})
+		assert.Equal(t, http.StatusBadRequest, decoded.Code)
+		assert.NotNil(t, decoded.Meta)
+	})
+
+	t.Run("returns error for invalid JSON", func(t *testing.T) {
+		var decoded HTTPError
+		assert.Error(t, json.Unmarshal([]byte(`{"code":`), &decoded))
+	})
+}
+
+func TestNewHTTPErrorFromJSON(t *testing.T) {
+	t.Run("creates HTTPError from JSON", func(t *testing.T) {
+		err, decodeErr := NewHTTPErrorFromJSON([]byte(`{"code":404,"message":"not found","meta":{"k":"v"}}`))
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		assert.Equal(t, "not found", err.Message)
+		assert.Equal(t, "v", err.Meta["k"])
+	})
+
+	t.Run("returns error for invalid JSON", func(t *testing.T) {
+		err, decodeErr := NewHTTPErrorFromJSON([]byte(`not json`))
+		assert.Error(t, decodeErr)
+		assert.Nil(t, err)
+	})
+}
+
+type closeTrackingReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeTrackingReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestFromJSONReader(t *testing.T) {
+	t.Run("decodes JSON", func(t *testing.T) {
+		err, decodeErr := FromJSONReader(strings.NewReader(`{"code":404,"message":"not found","meta":{"id":"1"}}`))
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		assert.Equal(t, "not found", err.Message)
+		assert.Equal(t, "1", err.Meta["id"])
+	})
+
+	t.Run("closes the reader", func(t *testing.T) {
+		r := &closeTrackingReader{Reader: strings.NewReader(`{"code":404,"message":"not found"}`)}
+		_, decodeErr := FromJSONReader(r)
+		assert.NoError(t, decodeErr)
+		assert.True(t, r.closed)
+	})
+
+	t.Run("closes the reader on invalid JSON", func(t *testing.T) {
+		r := &closeTrackingReader{Reader: strings.NewReader(`{`)}
+		err, decodeErr := FromJSONReader(r)
+		assert.Error(t, decodeErr)
+		assert.Nil(t, err)
+		assert.True(t, r.closed)
+	})
+}
+
+func TestMustFromJSONReader(t *testing.T) {
+	t.Run("returns decoded error", func(t *testing.T) {
+		err := MustFromJSONReader(strings.NewReader(`{"code":400,"message":"bad request"}`))
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+	})
+
+	t.Run("panics on invalid JSON", func(t *testing.T) {
+		assert.Panics(t, func() { MustFromJSONReader(strings.NewReader("not json")) })
+	})
+}
+
+func TestHTTPErrorLen(t *testing.T) {
+	t.Run("returns JSON length", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request")
+		n, lenErr := err.Len()
+		assert.NoError(t, lenErr)
+		assert.Equal(t, len(`{"code":400,"message":"bad request","meta":{},"schema_version":"1"}`), n)
+	})
+
+	t.Run("returns error for unencodable meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue("ch", make(chan int))
+		_, lenErr := err.Len()
+		assert.Error(t, lenErr)
+	})
+}
+
+func TestHTTPErrorMarshalJSONWithChain(t *testing.T) {
+	inner := NewHTTPError(http.StatusNotFound, "user not found").AddMetaValue("id", "1")
+	middle := WrapErrorf(http.StatusBadGateway, fmt.Errorf("repo: %w", inner), "upstream failed")
+	outer := WrapIfChanged(middle, http.StatusServiceUnavailable, "unavailable")
+
+	t.Run("nests wrapped HTTPErrors as causes", func(t *testing.T) {
+		data, err := outer.MarshalJSONWithChain()
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"code":503,"message":"unavailable","meta":{},"cause":{"code":502,"message":"upstream failed","meta":{},"cause":{"code":404,"message":"user not found","meta":{"id":"1"}}},"schema_version":"1"}`, string(data))
+	})
+
+	t.Run("omits cause for plain wrapped errors", func(t *testing.T) {
+		data, err := WrapError(http.StatusInternalServerError, errors.New("boom")).MarshalJSONWithChain()
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"code":500,"message":"boom","meta":{},"schema_version":"1"}`, string(data))
+	})
+
+	t.Run("MarshalJSON stays flat", func(t *testing.T) {
+		data, err := json.Marshal(outer)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"code":503,"message":"unavailable","meta":{},"schema_version":"1"}`, string(data))
+	})
+}
+
+func TestSetIncludeChainInJSON(t *testing.T) {
+	t.Run("makes MarshalJSON include the chain", func(t *testing.T) {
+		SetIncludeChainInJSON(true)
+		defer SetIncludeChainInJSON(false)
+
+		err := WrapIfChanged(NewHTTPError(http.StatusNotFound, "not found"), http.StatusBadGateway, "upstream failed")
+		data, marshalErr := json.Marshal(err)
+		assert.NoError(t, marshalErr)
+		assert.JSONEq(t, `{"code":502,"message":"upstream failed","meta":{},"cause":{"code":404,"message":"not found","meta":{}},"schema_version":"1"}`, string(data))
+	})
+}
+
+func TestSetJSONSchemaVersion(t *testing.T) {
+	t.Run("emits default version", func(t *testing.T) {
+		data, err := json.Marshal(NewHTTPError(http.StatusBadRequest, "bad request"))
+		assert.NoError(t, err)
+		assert.Equal(t, `{"code":400,"message":"bad request","meta":{},"schema_version":"1"}`, string(data))
+	})
+
+	t.Run("emits configured version", func(t *testing.T) {
+		SetJSONSchemaVersion("2")
+		defer SetJSONSchemaVersion("1")
+		data, err := json.Marshal(NewHTTPError(http.StatusBadRequest, "bad request"))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"code":400,"message":"bad request","meta":{},"schema_version":"2"}`, string(data))
+	})
+
+	t.Run("omits field when empty", func(t *testing.T) {
+		SetJSONSchemaVersion("")
+		defer SetJSONSchemaVersion("1")
+		data, err := json.Marshal(NewHTTPError(http.StatusBadRequest, "bad request"))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"code":400,"message":"bad request","meta":{}}`, string(data))
+	})
+}
+
+func TestGetJSONSchemaVersion(t *testing.T) {
+	t.Run("returns decoded version", func(t *testing.T) {
+		e, err := NewHTTPErrorFromJSON([]byte(`{"code":404,"message":"not found","meta":{},"schema_version":"3"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "3", GetJSONSchemaVersion(e))
+		assert.Equal(t, "3", GetJSONSchemaVersion(fmt.Errorf("client: %w", e)))
+		assert.NotContains(t, e.Meta, "schema_version")
+	})
+
+	t.Run("round trips current version", func(t *testing.T) {
+		data, err := json.Marshal(NewHTTPError(http.StatusNotFound, "not found"))
+		assert.NoError(t, err)
+		e, err := NewHTTPErrorFromJSON(data)
+		assert.NoError(t, err)
+		assert.Equal(t, "1", GetJSONSchemaVersion(e))
+	})
+
+	t.Run("returns empty string when not decoded", func(t *testing.T) {
+		e, err := NewHTTPErrorFromJSON([]byte(`{"code":404,"message":"not found"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "", GetJSONSchemaVersion(e))
+		assert.Equal(t, "", GetJSONSchemaVersion(NewHTTPError(http.StatusNotFound, "not found")))
+		assert.Equal(t, "", GetJSONSchemaVersion(errors.New("standard error")))
+	})
+}
@@ -0,0 +1,166 @@
+package httperror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HTTPErrorList collects multiple HTTPErrors, such as those produced by a batch operation,
+// so they can be returned as a single error. Nil entries, which can get in through
+// a literal or append, are skipped by all methods.
+type HTTPErrorList []*HTTPError
+
+// Add appends e to the list. Nil errors are ignored.
+func (l *HTTPErrorList) Add(e *HTTPError) {
+	if e == nil {
+		return
+	}
+	*l = append(*l, e)
+}
+
+// First returns the first error in the list, or nil if the list is empty.
+func (l HTTPErrorList) First() *HTTPError {
+	for _, e := range l {
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// Last returns the last error in the list, or nil if the list is empty.
+func (l HTTPErrorList) Last() *HTTPError {
+	for i := len(l) - 1; i >= 0; i-- {
+		if l[i] != nil {
+			return l[i]
+		}
+	}
+	return nil
+}
+
+// Len returns the number of errors in the list.
+func (l HTTPErrorList) Len() int {
+	n := 0
+	for _, e := range l {
+		if e != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Error joins the errors' codes and messages, e.g. "[400] bad request; [422] invalid email".
+func (l HTTPErrorList) Error() string {
+	parts := make([]string, 0, len(l))
+	for _, e := range l {
+		if e != nil {
+			parts = append(parts, fmt.Sprintf("[%d] %s", e.Code, e.Message))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Unwrap returns the errors in the list so errors.Is and errors.As can traverse them.
+func (l HTTPErrorList) Unwrap() []error {
+	errs := make([]error, 0, len(l))
+	for _, e := range l {
+		if e != nil {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+// FilterByStatus returns the errors in the list with the given status code.
+func (l HTTPErrorList) FilterByStatus(code int) HTTPErrorList {
+	var filtered HTTPErrorList
+	for _, e := range l {
+		if e != nil && e.Code == code {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// HasStatus reports whether any error in the list has the given status code.
+func (l HTTPErrorList) HasStatus(code int) bool {
+	for _, e := range l {
+		if e != nil && e.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByCategory returns the HTTPErrors in the chains of errs for which
+// predicate returns true. Nil entries and plain errors are skipped.
+func FilterByCategory(errs []error, predicate func(*HTTPError) bool) []*HTTPError {
+	var filtered []*HTTPError
+	for _, err := range errs {
+		if httpErr, ok := AsHTTPError(err); ok && predicate(httpErr) {
+			filtered = append(filtered, httpErr)
+		}
+	}
+	return filtered
+}
+
+// FilterByStatus returns the HTTPErrors in the chains of errs with the given status code.
+func FilterByStatus(errs []error, code int) []*HTTPError {
+	return FilterByCategory(errs, func(e *HTTPError) bool {
+		return e.Code == code
+	})
+}
+
+// FilterClientErrors returns the HTTPErrors in the chains of errs that are client errors.
+func FilterClientErrors(errs []error) []*HTTPError {
+	return FilterByCategory(errs, func(e *HTTPError) bool {
+		return IsClientError(e)
+	})
+}
+
+// FilterServerErrors returns the HTTPErrors in the chains of errs that are server errors.
+func FilterServerErrors(errs []error) []*HTTPError {
+	return FilterByCategory(errs, func(e *HTTPError) bool {
+		return IsServerError(e)
+	})
+}
+
+// PartitionErrors splits errs into client errors, server errors and all other
+// errors, preserving their order. Nil entries are dropped.
+func PartitionErrors(errs []error) (clientErrors, serverErrors, others []error) {
+	for _, err := range errs {
+		switch {
+		case err == nil:
+		case IsClientError(err):
+			clientErrors = append(clientErrors, err)
+		case IsServerError(err):
+			serverErrors = append(serverErrors, err)
+		default:
+			others = append(others, err)
+		}
+	}
+	return clientErrors, serverErrors, others
+}
+
+// PartitionByCode splits errs by status code, preserving their order. The result
+// holds one slice per code in codes followed by a slice of the remaining errors.
+// Nil entries are dropped.
+func PartitionByCode(errs []error, codes ...int) [][]error {
+	partitions := make([][]error, len(codes)+1)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		i := len(codes)
+		if httpErr, ok := AsHTTPError(err); ok {
+			for j, code := range codes {
+				if httpErr.Code == code {
+					i = j
+					break
+				}
+			}
+		}
+		partitions[i] = append(partitions[i], err)
+	}
+	return partitions
+}
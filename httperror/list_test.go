@@ -0,0 +1,199 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorList(t *testing.T) {
+	t.Run("empty list", func(t *testing.T) {
+		var list HTTPErrorList
+		assert.Equal(t, 0, list.Len())
+		assert.Nil(t, list.First())
+		assert.Nil(t, list.Last())
+		assert.Equal(t, "", list.Error())
+		assert.Empty(t, list.Unwrap())
+		assert.False(t, list.HasStatus(http.StatusBadRequest))
+		assert.Empty(t, list.FilterByStatus(http.StatusBadRequest))
+	})
+
+	t.Run("single element list", func(t *testing.T) {
+		var list HTTPErrorList
+		err := NewHTTPError(http.StatusBadRequest, "bad request")
+		list.Add(err)
+		list.Add(nil)
+		assert.Equal(t, 1, list.Len())
+		assert.Same(t, err, list.First())
+		assert.Same(t, err, list.Last())
+		assert.Equal(t, "[400] bad request", list.Error())
+	})
+
+	t.Run("mixed code list", func(t *testing.T) {
+		var list HTTPErrorList
+		list.Add(NewHTTPError(http.StatusBadRequest, "bad request"))
+		list.Add(NewHTTPError(http.StatusUnprocessableEntity, "invalid email"))
+		list.Add(NewHTTPError(http.StatusBadRequest, "missing name"))
+
+		assert.Equal(t, "[400] bad request; [422] invalid email; [400] missing name", list.Error())
+		assert.Equal(t, "missing name", list.Last().Message)
+		assert.True(t, list.HasStatus(http.StatusUnprocessableEntity))
+		assert.False(t, list.HasStatus(http.StatusNotFound))
+
+		filtered := list.FilterByStatus(http.StatusBadRequest)
+		assert.Equal(t, 2, filtered.Len())
+		assert.Equal(t, "bad request", filtered.First().Message)
+		assert.Equal(t, "missing name", filtered.Last().Message)
+	})
+
+	t.Run("skips nil entries", func(t *testing.T) {
+		first := NewHTTPError(http.StatusBadRequest, "bad request")
+		last := NewHTTPError(http.StatusConflict, "duplicate")
+		list := HTTPErrorList{nil, first, nil, last, nil}
+		assert.NotPanics(t, func() { _ = list.Error() })
+		assert.Equal(t, "[400] bad request; [409] duplicate", list.Error())
+		assert.Equal(t, 2, list.Len())
+		assert.Same(t, first, list.First())
+		assert.Same(t, last, list.Last())
+		assert.Equal(t, []error{first, last}, list.Unwrap())
+		assert.True(t, list.HasStatus(http.StatusConflict))
+		assert.Equal(t, HTTPErrorList{first}, list.FilterByStatus(http.StatusBadRequest))
+
+		nilOnly := HTTPErrorList{nil}
+		assert.Equal(t, "", nilOnly.Error())
+		assert.Nil(t, nilOnly.First())
+		assert.Nil(t, nilOnly.Last())
+		assert.Empty(t, nilOnly.Unwrap())
+		assert.False(t, errors.As(nilOnly, new(*HTTPError)))
+	})
+
+	t.Run("errors.As traverses list", func(t *testing.T) {
+		list := HTTPErrorList{
+			NewHTTPError(http.StatusUnprocessableEntity, "invalid email"),
+			NewHTTPError(http.StatusConflict, "duplicate"),
+		}
+		var err error = list
+
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+		assert.True(t, errors.Is(err, NewHTTPError(http.StatusConflict, "")))
+		assert.True(t, IsStatus(err, http.StatusUnprocessableEntity))
+	})
+}
+
+func TestFilterByStatus(t *testing.T) {
+	notFound := NewHTTPError(http.StatusNotFound, "not found")
+	conflict := NewHTTPError(http.StatusConflict, "conflict")
+
+	t.Run("returns nothing for empty input", func(t *testing.T) {
+		assert.Empty(t, FilterByStatus(nil, http.StatusNotFound))
+	})
+
+	t.Run("returns nothing when no error matches", func(t *testing.T) {
+		assert.Empty(t, FilterByStatus([]error{conflict}, http.StatusNotFound))
+	})
+
+	t.Run("returns all matching errors", func(t *testing.T) {
+		other := NewHTTPError(http.StatusNotFound, "other")
+		assert.Equal(t, []*HTTPError{notFound, other}, FilterByStatus([]error{notFound, other}, http.StatusNotFound))
+	})
+
+	t.Run("handles mixed, wrapped and nil entries", func(t *testing.T) {
+		errs := []error{nil, errors.New("plain"), fmt.Errorf("wrapped: %w", notFound), conflict}
+		assert.Equal(t, []*HTTPError{notFound}, FilterByStatus(errs, http.StatusNotFound))
+	})
+}
+
+func TestFilterByCategory(t *testing.T) {
+	t.Run("applies predicate", func(t *testing.T) {
+		tagged := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue("field", "email")
+		errs := []error{tagged, NewHTTPError(http.StatusBadRequest, "bad request"), errors.New("plain"), nil}
+		filtered := FilterByCategory(errs, func(e *HTTPError) bool {
+			return e.Meta["field"] != nil
+		})
+		assert.Equal(t, []*HTTPError{tagged}, filtered)
+	})
+}
+
+func TestFilterClientErrors(t *testing.T) {
+	t.Run("returns 4XX errors", func(t *testing.T) {
+		badRequest := NewHTTPError(http.StatusBadRequest, "bad request")
+		errs := []error{badRequest, NewHTTPError(http.StatusBadGateway, "bad gateway"), errors.New("plain"), nil}
+		assert.Equal(t, []*HTTPError{badRequest}, FilterClientErrors(errs))
+		assert.Empty(t, FilterClientErrors(nil))
+	})
+}
+
+func TestFilterServerErrors(t *testing.T) {
+	t.Run("returns 5XX errors", func(t *testing.T) {
+		badGateway := NewHTTPError(http.StatusBadGateway, "bad gateway")
+		errs := []error{NewHTTPError(http.StatusBadRequest, "bad request"), badGateway, errors.New("plain"), nil}
+		assert.Equal(t, []*HTTPError{badGateway}, FilterServerErrors(errs))
+		assert.Empty(t, FilterServerErrors(nil))
+	})
+}
+
+func TestPartitionErrors(t *testing.T) {
+	t.Run("empty input", func(t *testing.T) {
+		clientErrs, serverErrs, others := PartitionErrors(nil)
+		assert.Empty(t, clientErrs)
+		assert.Empty(t, serverErrs)
+		assert.Empty(t, others)
+	})
+
+	t.Run("all same category", func(t *testing.T) {
+		errs := []error{
+			NewHTTPError(http.StatusBadRequest, "bad request"),
+			NewHTTPError(http.StatusNotFound, "not found"),
+		}
+		clientErrs, serverErrs, others := PartitionErrors(errs)
+		assert.Equal(t, errs, clientErrs)
+		assert.Empty(t, serverErrs)
+		assert.Empty(t, others)
+	})
+
+	t.Run("mixed categories", func(t *testing.T) {
+		badRequest := NewHTTPError(http.StatusBadRequest, "bad request")
+		wrapped := fmt.Errorf("wrapped: %w", NewHTTPError(http.StatusBadGateway, "bad gateway"))
+		plain := errors.New("plain")
+		redirect := NewHTTPError(http.StatusFound, "found")
+		clientErrs, serverErrs, others := PartitionErrors([]error{plain, badRequest, nil, wrapped, redirect, nil})
+		assert.Equal(t, []error{badRequest}, clientErrs)
+		assert.Equal(t, []error{wrapped}, serverErrs)
+		assert.Equal(t, []error{plain, redirect}, others)
+	})
+}
+
+func TestPartitionByCode(t *testing.T) {
+	t.Run("empty input", func(t *testing.T) {
+		partitions := PartitionByCode(nil, http.StatusNotFound)
+		assert.Len(t, partitions, 2)
+		assert.Empty(t, partitions[0])
+		assert.Empty(t, partitions[1])
+	})
+
+	t.Run("preserves order within partitions", func(t *testing.T) {
+		notFound1 := NewHTTPError(http.StatusNotFound, "first")
+		notFound2 := fmt.Errorf("wrapped: %w", NewHTTPError(http.StatusNotFound, "second"))
+		conflict := NewHTTPError(http.StatusConflict, "conflict")
+		badGateway := NewHTTPError(http.StatusBadGateway, "bad gateway")
+		plain := errors.New("plain")
+		errs := []error{badGateway, notFound1, nil, conflict, plain, notFound2}
+
+		partitions := PartitionByCode(errs, http.StatusNotFound, http.StatusConflict)
+		assert.Equal(t, [][]error{
+			{notFound1, notFound2},
+			{conflict},
+			{badGateway, plain},
+		}, partitions)
+	})
+
+	t.Run("no codes returns remainder only", func(t *testing.T) {
+		plain := errors.New("plain")
+		assert.Equal(t, [][]error{{plain}}, PartitionByCode([]error{nil, plain}))
+	})
+}
@@ -0,0 +1,39 @@
+package httperror
+
+import "errors"
+
+// ErrorMapper converts errors into HTTPErrors using registered target errors.
+// Targets are matched with errors.Is in registration order.
+type ErrorMapper struct {
+	mappings []errorMapping
+}
+
+type errorMapping struct {
+	target error
+	code   int
+}
+
+// NewErrorMapper creates a new, empty ErrorMapper.
+func NewErrorMapper() *ErrorMapper {
+	return &ErrorMapper{}
+}
+
+// Register maps errors matching target to the given status code.
+func (m *ErrorMapper) Register(target error, code int) *ErrorMapper {
+	m.mappings = append(m.mappings, errorMapping{target: target, code: code})
+	return m
+}
+
+// Map converts err to an HTTPError using the first matching registration.
+// Errors without a matching registration are converted with ToHTTPError.
+func (m *ErrorMapper) Map(err error) *HTTPError {
+	if err == nil {
+		return nil
+	}
+	for _, mapping := range m.mappings {
+		if errors.Is(err, mapping.target) {
+			return WrapError(mapping.code, err)
+		}
+	}
+	return ToHTTPError(err)
+}
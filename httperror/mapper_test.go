@@ -0,0 +1,43 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorMapper(t *testing.T) {
+	errMissing := errors.New("missing")
+	errBusy := errors.New("busy")
+	mapper := NewErrorMapper().
+		Register(errMissing, http.StatusNotFound).
+		Register(errBusy, http.StatusServiceUnavailable)
+
+	t.Run("maps registered errors", func(t *testing.T) {
+		httpErr := mapper.Map(errMissing)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+		assert.Equal(t, errMissing, errors.Unwrap(httpErr))
+	})
+
+	t.Run("maps wrapped registered errors", func(t *testing.T) {
+		httpErr := mapper.Map(fmt.Errorf("loading: %w", errBusy))
+		assert.Equal(t, http.StatusServiceUnavailable, httpErr.Code)
+	})
+
+	t.Run("falls back to 500 for unregistered errors", func(t *testing.T) {
+		httpErr := mapper.Map(errors.New("unknown"))
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+	})
+
+	t.Run("returns existing HTTPError", func(t *testing.T) {
+		existingErr := NewHTTPError(http.StatusConflict, "conflict")
+		assert.Equal(t, existingErr, mapper.Map(existingErr))
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, mapper.Map(nil))
+	})
+}
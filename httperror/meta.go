@@ -0,0 +1,153 @@
+package httperror
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// OperationMetaKey is the Meta key used to store the failed operation.
+	OperationMetaKey = "operation"
+	// APIVersionMetaKey is the Meta key used to store the API version.
+	APIVersionMetaKey = "api_version"
+	// RequestBodyMetaKey is the Meta key used to store the failed request body.
+	RequestBodyMetaKey = "request_body"
+	// ResponseTimeMetaKey is the Meta key used to store the handler latency in milliseconds.
+	ResponseTimeMetaKey = "response_time_ms"
+	// EnvironmentMetaKey is the Meta key used to store the deployment environment.
+	EnvironmentMetaKey = "environment"
+	// AppVersionMetaKey is the Meta key used to store the application version.
+	AppVersionMetaKey = "app_version"
+	// ServiceNameMetaKey is the Meta key used to store the name of the service that generated the error.
+	ServiceNameMetaKey = "service_name"
+	// RequestMethodMetaKey is the Meta key used to store the method of the failed request.
+	RequestMethodMetaKey = "request_method"
+	// RequestPathMetaKey is the Meta key used to store the URL path of the failed request.
+	RequestPathMetaKey = "request_path"
+)
+
+// sensitiveMeta controls whether helpers that may record PII store their values.
+var sensitiveMeta atomic.Bool
+
+// EnableSensitiveMeta allows helpers such as WithDatabaseQuery, whose values may
+// contain PII, to store them in Meta. They are no-ops by default.
+func EnableSensitiveMeta() {
+	sensitiveMeta.Store(true)
+}
+
+// DisableSensitiveMeta restores the default of not storing sensitive Meta values.
+func DisableSensitiveMeta() {
+	sensitiveMeta.Store(false)
+}
+
+// truncatedSuffix is appended to values truncated before being stored in Meta.
+const truncatedSuffix = "[truncated]"
+
+// metaString returns the string stored under key in the first HTTPError found in err's chain.
+func metaString(err error, key string) string {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return ""
+	}
+	value, _ := httpErr.Meta[key].(string)
+	return value
+}
+
+// WithOperation records the operation that failed, such as "db.query" or "cache.get".
+func (e *HTTPError) WithOperation(op string) *HTTPError {
+	return e.AddMetaValue(OperationMetaKey, op)
+}
+
+// GetOperation returns the operation recorded on the HTTPError in err's chain.
+func GetOperation(err error) string {
+	return metaString(err, OperationMetaKey)
+}
+
+// IsOperationError checks if the provided error is an HTTPError recorded for the given operation.
+func IsOperationError(err error, op string) bool {
+	return IsHTTPError(err) && GetOperation(err) == op
+}
+
+// WithAPIVersion records the version of the API that generated the error.
+func (e *HTTPError) WithAPIVersion(version string) *HTTPError {
+	return e.AddMetaValue(APIVersionMetaKey, version)
+}
+
+// GetAPIVersion returns the API version recorded on the HTTPError in err's chain.
+func GetAPIVersion(err error) string {
+	return metaString(err, APIVersionMetaKey)
+}
+
+// WithRequestBody records up to maxSize bytes of the request body that caused the error.
+// Larger bodies are truncated and suffixed with "[truncated]".
+func (e *HTTPError) WithRequestBody(body []byte, maxSize int) *HTTPError {
+	if maxSize >= 0 && len(body) > maxSize {
+		return e.AddMetaValue(RequestBodyMetaKey, string(body[:maxSize])+truncatedSuffix)
+	}
+	return e.AddMetaValue(RequestBodyMetaKey, string(body))
+}
+
+// GetRequestBody returns the request body recorded on the HTTPError in err's chain.
+func GetRequestBody(err error) []byte {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return nil
+	}
+	switch v := httpErr.Meta[RequestBodyMetaKey].(type) {
+	case string:
+		return []byte(v)
+	case []byte:
+		return v
+	}
+	return nil
+}
+
+// WithResponseTime records the handler latency in milliseconds.
+// Since it is stored in Meta, it is included in the JSON body of the error response.
+func (e *HTTPError) WithResponseTime(d time.Duration) *HTTPError {
+	return e.AddMetaValue(ResponseTimeMetaKey, float64(d)/float64(time.Millisecond))
+}
+
+// GetResponseTime returns the handler latency recorded on the HTTPError in err's chain.
+func GetResponseTime(err error) (time.Duration, bool) {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return 0, false
+	}
+	ms, ok := httpErr.Meta[ResponseTimeMetaKey].(float64)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(ms * float64(time.Millisecond)), true
+}
+
+// WithEnvironment records the deployment environment, such as "staging" or "prod".
+func (e *HTTPError) WithEnvironment(env string) *HTTPError {
+	return e.AddMetaValue(EnvironmentMetaKey, env)
+}
+
+// GetEnvironment returns the environment recorded on the HTTPError in err's chain.
+func GetEnvironment(err error) string {
+	return metaString(err, EnvironmentMetaKey)
+}
+
+// WithAppVersion records the version of the application that generated the error.
+func (e *HTTPError) WithAppVersion(version string) *HTTPError {
+	return e.AddMetaValue(AppVersionMetaKey, version)
+}
+
+// GetAppVersion returns the application version recorded on the HTTPError in err's chain.
+func GetAppVersion(err error) string {
+	return metaString(err, AppVersionMetaKey)
+}
+
+// WithServiceName records the name of the service that generated the error.
+func (e *HTTPError) WithServiceName(name string) *HTTPError {
+	return e.AddMetaValue(ServiceNameMetaKey, name)
+}
+
+// GetServiceName returns the service name recorded on the HTTPError in err's chain.
+func GetServiceName(err error) string {
+	return metaString(err, ServiceNameMetaKey)
+}
@@ -0,0 +1,197 @@
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorWithOperation(t *testing.T) {
+	t.Run("stores operation in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "query failed").WithOperation("db.query")
+		assert.Equal(t, "db.query", err.Meta[OperationMetaKey])
+	})
+}
+
+func TestGetOperation(t *testing.T) {
+	t.Run("returns operation from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "query failed").WithOperation("db.query")
+		assert.Equal(t, "db.query", GetOperation(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", GetOperation(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "", GetOperation(errors.New("standard error")))
+	})
+}
+
+func TestIsOperationError(t *testing.T) {
+	t.Run("returns true for matching operation", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "miss").WithOperation("cache.get")
+		assert.True(t, IsOperationError(err, "cache.get"))
+	})
+
+	t.Run("returns false for different operation", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "miss").WithOperation("cache.get")
+		assert.False(t, IsOperationError(err, "db.query"))
+	})
+
+	t.Run("returns false for non-HTTPError", func(t *testing.T) {
+		assert.False(t, IsOperationError(errors.New("standard error"), ""))
+	})
+}
+
+func TestHTTPErrorWithAPIVersion(t *testing.T) {
+	t.Run("stores api version in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithAPIVersion("v2")
+		assert.Equal(t, "v2", err.Meta[APIVersionMetaKey])
+	})
+}
+
+func TestGetAPIVersion(t *testing.T) {
+	t.Run("returns api version from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithAPIVersion("v2")
+		assert.Equal(t, "v2", GetAPIVersion(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", GetAPIVersion(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "", GetAPIVersion(errors.New("standard error")))
+	})
+}
+
+func TestHTTPErrorWithRequestBody(t *testing.T) {
+	t.Run("stores request body", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithRequestBody([]byte(`{"name":""}`), 100)
+		assert.Equal(t, `{"name":""}`, err.Meta[RequestBodyMetaKey])
+	})
+
+	t.Run("truncates large request body", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithRequestBody([]byte("0123456789"), 4)
+		assert.Equal(t, "0123[truncated]", err.Meta[RequestBodyMetaKey])
+	})
+
+	t.Run("does not truncate body at max size", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithRequestBody([]byte("0123"), 4)
+		assert.Equal(t, "0123", err.Meta[RequestBodyMetaKey])
+	})
+}
+
+func TestGetRequestBody(t *testing.T) {
+	t.Run("returns request body from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnprocessableEntity, "invalid").WithRequestBody([]byte("body"), 10)
+		assert.Equal(t, []byte("body"), GetRequestBody(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns nil when not set", func(t *testing.T) {
+		assert.Nil(t, GetRequestBody(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Nil(t, GetRequestBody(errors.New("standard error")))
+	})
+}
+
+func TestHTTPErrorWithResponseTime(t *testing.T) {
+	t.Run("stores response time in milliseconds", func(t *testing.T) {
+		err := NewHTTPError(http.StatusGatewayTimeout, "timeout").WithResponseTime(1500 * time.Microsecond)
+		assert.Equal(t, 1.5, err.Meta[ResponseTimeMetaKey])
+	})
+
+	t.Run("includes response time in JSON", func(t *testing.T) {
+		err := NewHTTPError(http.StatusGatewayTimeout, "timeout").WithResponseTime(250 * time.Millisecond)
+		data, marshalErr := json.Marshal(err)
+		assert.NoError(t, marshalErr)
+		assert.JSONEq(t, `{"code":504,"message":"timeout","meta":{"response_time_ms":250},"schema_version":"1"}`, string(data))
+	})
+}
+
+func TestGetResponseTime(t *testing.T) {
+	t.Run("returns response time from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusGatewayTimeout, "timeout").WithResponseTime(250 * time.Millisecond)
+		d, ok := GetResponseTime(fmt.Errorf("handler: %w", err))
+		assert.True(t, ok)
+		assert.Equal(t, 250*time.Millisecond, d)
+	})
+
+	t.Run("round-trips through JSON", func(t *testing.T) {
+		data, _ := json.Marshal(NewHTTPError(http.StatusGatewayTimeout, "timeout").WithResponseTime(2 * time.Second))
+		decoded, decodeErr := NewHTTPErrorFromJSON(data)
+		assert.NoError(t, decodeErr)
+		d, ok := GetResponseTime(decoded)
+		assert.True(t, ok)
+		assert.Equal(t, 2*time.Second, d)
+	})
+
+	t.Run("returns false when not set", func(t *testing.T) {
+		_, ok := GetResponseTime(NewHTTPError(http.StatusBadRequest, "bad request"))
+		assert.False(t, ok)
+		_, ok = GetResponseTime(errors.New("standard error"))
+		assert.False(t, ok)
+	})
+}
+
+func TestHTTPErrorWithEnvironment(t *testing.T) {
+	t.Run("stores environment in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithEnvironment("staging")
+		assert.Equal(t, "staging", err.Meta[EnvironmentMetaKey])
+	})
+}
+
+func TestGetEnvironment(t *testing.T) {
+	t.Run("returns environment from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithEnvironment("prod")
+		assert.Equal(t, "prod", GetEnvironment(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", GetEnvironment(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "", GetEnvironment(errors.New("standard error")))
+	})
+}
+
+func TestHTTPErrorWithAppVersion(t *testing.T) {
+	t.Run("stores app version in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithAppVersion("1.4.2")
+		assert.Equal(t, "1.4.2", err.Meta[AppVersionMetaKey])
+	})
+
+	t.Run("includes app version in JSON", func(t *testing.T) {
+		data, marshalErr := json.Marshal(NewHTTPError(http.StatusBadRequest, "bad request").WithAppVersion("1.4.2"))
+		assert.NoError(t, marshalErr)
+		assert.JSONEq(t, `{"code":400,"message":"bad request","meta":{"app_version":"1.4.2"},"schema_version":"1"}`, string(data))
+	})
+}
+
+func TestGetAppVersion(t *testing.T) {
+	t.Run("returns app version from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithAppVersion("1.4.2")
+		assert.Equal(t, "1.4.2", GetAppVersion(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", GetAppVersion(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "", GetAppVersion(errors.New("standard error")))
+	})
+}
+
+func TestHTTPErrorWithServiceName(t *testing.T) {
+	t.Run("stores service name in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithServiceName("billing")
+		assert.Equal(t, "billing", err.Meta[ServiceNameMetaKey])
+	})
+}
+
+func TestGetServiceName(t *testing.T) {
+	t.Run("returns service name from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithServiceName("billing")
+		assert.Equal(t, "billing", GetServiceName(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", GetServiceName(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "", GetServiceName(errors.New("standard error")))
+	})
+}
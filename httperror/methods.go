@@ -0,0 +1,40 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// AllowedMethodsMetaKey is the Meta key used to store the methods allowed for a resource.
+const AllowedMethodsMetaKey = "allowed_methods"
+
+// NewMethodNotAllowedWithMethods creates a 405 HTTPError listing the allowed methods
+// in Meta and in the Allow response header.
+func NewMethodNotAllowedWithMethods(allowed ...string) *HTTPError {
+	return newHTTPError(http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed), nil, func(e *HTTPError) {
+		e.AddMetaValue(AllowedMethodsMetaKey, allowed)
+		e.setHeader("Allow", strings.Join(allowed, ", "))
+	})
+}
+
+// GetAllowedMethods returns the allowed methods recorded on the HTTPError in err's chain.
+func GetAllowedMethods(err error) []string {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return nil
+	}
+	switch v := httpErr.Meta[AllowedMethodsMetaKey].(type) {
+	case []string:
+		return v
+	case []any:
+		methods := make([]string, 0, len(v))
+		for _, m := range v {
+			if s, ok := m.(string); ok {
+				methods = append(methods, s)
+			}
+		}
+		return methods
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMethodNotAllowedWithMethods(t *testing.T) {
+	t.Run("creates 405 with allowed methods", func(t *testing.T) {
+		err := NewMethodNotAllowedWithMethods(http.MethodGet, http.MethodPost)
+		assert.Equal(t, http.StatusMethodNotAllowed, err.Code)
+		assert.Equal(t, []string{"GET", "POST"}, err.Meta[AllowedMethodsMetaKey])
+		assert.Equal(t, "GET, POST", err.Header.Get("Allow"))
+	})
+}
+
+func TestGetAllowedMethods(t *testing.T) {
+	t.Run("returns allowed methods from wrapped error", func(t *testing.T) {
+		err := fmt.Errorf("router: %w", NewMethodNotAllowedWithMethods(http.MethodGet))
+		assert.Equal(t, []string{"GET"}, GetAllowedMethods(err))
+	})
+
+	t.Run("returns allowed methods after JSON round-trip", func(t *testing.T) {
+		data, _ := json.Marshal(NewMethodNotAllowedWithMethods(http.MethodGet, http.MethodPut))
+		decoded, decodeErr := NewHTTPErrorFromJSON(data)
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, []string{"GET", "PUT"}, GetAllowedMethods(decoded))
+	})
+
+	t.Run("returns nil when not set", func(t *testing.T) {
+		assert.Nil(t, GetAllowedMethods(NewHTTPError(http.StatusMethodNotAllowed, "not allowed")))
+		assert.Nil(t, GetAllowedMethods(errors.New("standard error")))
+	})
+}
+
+func TestWriteJSONResponseAllowHeader(t *testing.T) {
+	t.Run("sets Allow header for 405 errors", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, NewMethodNotAllowedWithMethods(http.MethodGet, http.MethodHead))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+	})
+
+	t.Run("sets Allow header from meta", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		err := NewHTTPError(http.StatusMethodNotAllowed, "not allowed").AddMetaValue(AllowedMethodsMetaKey, []string{"POST"})
+		WriteJSONResponse(rec, err)
+		assert.Equal(t, "POST", rec.Header().Get("Allow"))
+	})
+
+	t.Run("does not set Allow header for other errors", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		err := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue(AllowedMethodsMetaKey, []string{"POST"})
+		WriteJSONResponse(rec, err)
+		assert.Empty(t, rec.Header().Get("Allow"))
+	})
+}
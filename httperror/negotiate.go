@@ -0,0 +1,206 @@
+package httperror
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	contentTypeJSON = "application/json; charset=utf-8"
+	contentTypeXML  = "application/xml; charset=utf-8"
+)
+
+// WithJSONBody pre-serializes v as the JSON body written by WriteNegotiatedResponse.
+// If v cannot be encoded, WriteNegotiatedResponse writes a 500 for JSON clients
+// and returns the encoding error.
+func (e *HTTPError) WithJSONBody(v any) *HTTPError {
+	e.jsonBody, e.jsonBodyErr = json.Marshal(v)
+	return e
+}
+
+// WithXMLBody pre-serializes v as the XML body written by WriteNegotiatedResponse.
+// If v cannot be encoded, WriteNegotiatedResponse writes a 500 for XML clients
+// and returns the encoding error.
+func (e *HTTPError) WithXMLBody(v any) *HTTPError {
+	e.xmlBody, e.xmlBodyErr = xml.Marshal(v)
+	return e
+}
+
+// WriteNegotiatedResponse writes e as JSON or XML depending on the request's Accept header.
+// Pre-serialized bodies set with WithJSONBody or WithXMLBody take precedence over
+// the HTTPError's own representation, which has sensitive Meta values redacted.
+// JSON is used when neither format is preferred. If the body cannot be encoded,
+// a 500 is written instead. It returns the error from encoding or writing the body.
+func WriteNegotiatedResponse(w http.ResponseWriter, r *http.Request, e *HTTPError) error {
+	if e == nil {
+		return nil
+	}
+	contentType := negotiateContentType(r.Header.Get("Accept"), []string{"application/json", "application/xml", "text/xml"})
+
+	var body []byte
+	var err error
+	switch contentType {
+	case "application/xml", "text/xml":
+		contentType = contentTypeXML
+		body, err = e.xmlBody, e.xmlBodyErr
+		if body == nil && err == nil {
+			body, err = xml.Marshal(e.Redacted())
+		}
+	default:
+		contentType = contentTypeJSON
+		body, err = e.jsonBody, e.jsonBodyErr
+		if body == nil && err == nil {
+			body, err = json.Marshal(e.Redacted())
+		}
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return err
+	}
+	return writeBody(w, e, e.Code, contentType, body)
+}
+
+// responseSerializer writes an HTTPError in a registered content type.
+type responseSerializer struct {
+	contentType string
+	fn          func(w io.Writer, e *HTTPError) error
+}
+
+var (
+	serializersMu sync.RWMutex
+	// serializerTypes lists the registered media types in negotiation order.
+	serializerTypes = []string{"application/json", "application/xml", "text/xml"}
+	serializers     = map[string]responseSerializer{
+		"application/json": {contentType: contentTypeJSON, fn: serializeJSON},
+		"application/xml":  {contentType: contentTypeXML, fn: serializeXML},
+		"text/xml":         {contentType: contentTypeXML, fn: serializeXML},
+	}
+)
+
+// serializeJSON writes e as JSON.
+func serializeJSON(w io.Writer, e *HTTPError) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// serializeXML writes e as an XML document.
+func serializeXML(w io.Writer, e *HTTPError) error {
+	data, err := xml.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, xml.Header)
+	if err == nil {
+		_, err = w.Write(data)
+	}
+	return err
+}
+
+// RegisterResponseSerializer registers fn to write errors for clients that accept
+// contentType, such as "application/msgpack". Registering a content type again
+// replaces its serializer, including the built-in JSON and XML serializers.
+func RegisterResponseSerializer(contentType string, fn func(w io.Writer, e *HTTPError) error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	serializersMu.Lock()
+	defer serializersMu.Unlock()
+	if _, ok := serializers[mediaType]; !ok {
+		serializerTypes = append(serializerTypes, mediaType)
+	}
+	serializers[mediaType] = responseSerializer{contentType: contentType, fn: fn}
+}
+
+// WriteResponse writes err to w in the format the request's Accept header
+// prefers among JSON, XML and the registered serializers. JSON is used when
+// nothing matches. Errors that are not HTTPErrors are written with the default
+// code and a nil err writes nothing. Sensitive Meta values are redacted; see
+// RegisterSensitiveKey. It returns the error from serializing or writing the body.
+func WriteResponse(w http.ResponseWriter, r *http.Request, err error) error {
+	httpErr := ToHTTPError(err)
+	if httpErr == nil {
+		return nil
+	}
+	serializersMu.RLock()
+	s := serializers[negotiateContentType(r.Header.Get("Accept"), serializerTypes)]
+	serializersMu.RUnlock()
+
+	var body bytes.Buffer
+	if serializeErr := s.fn(&body, httpErr.Redacted()); serializeErr != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return serializeErr
+	}
+	return writeBody(w, httpErr, httpErr.Code, s.contentType, body.Bytes())
+}
+
+// acceptRange is a single media range from an Accept header.
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+// negotiateContentType returns the offer that best matches the Accept header.
+// The first offer is returned when the header is empty or nothing matches.
+func negotiateContentType(accept string, offers []string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	ranges := parseAccept(accept)
+	for _, ar := range ranges {
+		if ar.quality <= 0 {
+			continue
+		}
+		for _, offer := range offers {
+			if matchMediaRange(ar.mediaType, offer) {
+				return offer
+			}
+		}
+	}
+	return offers[0]
+}
+
+// parseAccept parses an Accept header into media ranges ordered by preference.
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+	return ranges
+}
+
+// matchMediaRange reports whether offer matches a media range such as "application/*".
+func matchMediaRange(mediaRange, offer string) bool {
+	if mediaRange == "*/*" || mediaRange == offer {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(mediaRange, "/*"); ok {
+		return strings.HasPrefix(offer, prefix+"/")
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type negotiatedBody struct {
+	Error string `json:"error" xml:"error"`
+}
+
+func negotiatedRequest(accept string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	return r
+}
+
+func TestWriteNegotiatedResponse(t *testing.T) {
+	err := NewHTTPError(http.StatusNotFound, "not found").
+		WithJSONBody(negotiatedBody{Error: "json"}).
+		WithXMLBody(negotiatedBody{Error: "xml"})
+
+	t.Run("writes JSON body for JSON clients", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteNegotiatedResponse(rec, negotiatedRequest("application/json"), err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `{"error":"json"}`, rec.Body.String())
+	})
+
+	t.Run("writes XML body for XML clients", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteNegotiatedResponse(rec, negotiatedRequest("text/html, application/xml;q=0.9"), err)
+		assert.Equal(t, "application/xml; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `<negotiatedBody><error>xml</error></negotiatedBody>`, rec.Body.String())
+	})
+
+	t.Run("respects quality values", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteNegotiatedResponse(rec, negotiatedRequest("application/json;q=0.5, application/xml"), err)
+		assert.Equal(t, "application/xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("defaults to JSON", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "text/html"} {
+			rec := httptest.NewRecorder()
+			WriteNegotiatedResponse(rec, negotiatedRequest(accept), err)
+			assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"), accept)
+		}
+	})
+
+	t.Run("falls back to HTTPError representation", func(t *testing.T) {
+		plain := NewHTTPError(http.StatusBadRequest, "bad request")
+
+		rec := httptest.NewRecorder()
+		WriteNegotiatedResponse(rec, negotiatedRequest("application/json"), plain)
+		assert.JSONEq(t, `{"code":400,"message":"bad request","meta":{},"schema_version":"1"}`, rec.Body.String())
+
+		rec = httptest.NewRecorder()
+		WriteNegotiatedResponse(rec, negotiatedRequest("application/xml"), plain)
+		assert.Equal(t, `<error><code>400</code><message>bad request</message><meta></meta></error>`, rec.Body.String())
+	})
+
+	t.Run("returns error for bodies that cannot be encoded", func(t *testing.T) {
+		plain := NewHTTPError(http.StatusBadRequest, "bad request").WithJSONBody(make(chan int))
+		rec := httptest.NewRecorder()
+		assert.Error(t, WriteNegotiatedResponse(rec, negotiatedRequest("application/json"), plain))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		rec = httptest.NewRecorder()
+		assert.NoError(t, WriteNegotiatedResponse(rec, negotiatedRequest("application/xml"), plain))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("sets derived headers and Content-Length", func(t *testing.T) {
+		traced := NewHTTPError(http.StatusBadRequest, "bad request").WithTraceParent(testTraceParent)
+		for _, accept := range []string{"application/json", "application/xml"} {
+			rec := httptest.NewRecorder()
+			assert.NoError(t, WriteNegotiatedResponse(rec, negotiatedRequest(accept), traced))
+			assert.Equal(t, testTraceParent, rec.Header().Get("traceparent"), accept)
+			assert.Equal(t, strconv.Itoa(rec.Body.Len()), rec.Header().Get("Content-Length"), accept)
+		}
+	})
+}
+
+// unregisterResponseSerializer removes a serializer registered by a test.
+func unregisterResponseSerializer(mediaType string) {
+	serializersMu.Lock()
+	defer serializersMu.Unlock()
+	delete(serializers, mediaType)
+	serializerTypes = slices.DeleteFunc(serializerTypes, func(t string) bool { return t == mediaType })
+}
+
+func TestWriteResponse(t *testing.T) {
+	notFound := NewHTTPError(http.StatusNotFound, "not found")
+
+	t.Run("writes JSON", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		assert.NoError(t, WriteResponse(rec, negotiatedRequest("application/json"), notFound))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, contentTypeJSON, rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"code":404,"message":"not found","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("writes XML", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		assert.NoError(t, WriteResponse(rec, negotiatedRequest("application/xml"), notFound))
+		assert.Equal(t, contentTypeXML, rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "<error><code>404</code><message>not found</message>")
+	})
+
+	t.Run("defaults to JSON", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "text/html"} {
+			rec := httptest.NewRecorder()
+			assert.NoError(t, WriteResponse(rec, negotiatedRequest(accept), notFound))
+			assert.Equal(t, contentTypeJSON, rec.Header().Get("Content-Type"), accept)
+		}
+	})
+
+	t.Run("writes plain errors as 500", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		assert.NoError(t, WriteResponse(rec, negotiatedRequest(""), errors.New("boom")))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("writes nothing for nil error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		assert.NoError(t, WriteResponse(rec, negotiatedRequest(""), nil))
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("uses registered serializer", func(t *testing.T) {
+		RegisterResponseSerializer("text/plain; charset=utf-8", func(w io.Writer, e *HTTPError) error {
+			_, err := fmt.Fprintf(w, "%d %s", e.Code, e.Message)
+			return err
+		})
+		defer unregisterResponseSerializer("text/plain")
+
+		rec := httptest.NewRecorder()
+		assert.NoError(t, WriteResponse(rec, negotiatedRequest("text/plain"), notFound))
+		assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "404 not found", rec.Body.String())
+	})
+
+	t.Run("returns serializer error", func(t *testing.T) {
+		RegisterResponseSerializer("application/x-failing", func(w io.Writer, e *HTTPError) error {
+			return errors.New("serialize failed")
+		})
+		defer unregisterResponseSerializer("application/x-failing")
+
+		rec := httptest.NewRecorder()
+		assert.EqualError(t, WriteResponse(rec, negotiatedRequest("application/x-failing"), notFound), "serialize failed")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("returns write error", func(t *testing.T) {
+		w := failingResponseWriter{httptest.NewRecorder()}
+		assert.EqualError(t, WriteResponse(w, negotiatedRequest(""), notFound), "write failed")
+	})
+}
@@ -0,0 +1,322 @@
+package httperror
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIValidationMiddleware validates 4xx and 5xx response bodies against the
+// OpenAPI document at schemaPath. Mismatches are logged as warnings, and error
+// responses with no matching response definition are replaced with a 500.
+// It is intended for development and panics if the document cannot be loaded.
+func OpenAPIValidationMiddleware(schemaPath string) func(http.Handler) http.Handler {
+	spec, err := loadOpenAPISpec(schemaPath)
+	if err != nil {
+		panic(fmt.Sprintf("httperror: loading OpenAPI spec %q: %v", schemaPath, err))
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := newBufferedResponseWriter()
+			next.ServeHTTP(rec, r)
+
+			if rec.code >= http.StatusBadRequest {
+				schema, ok := spec.responseSchema(r.Method, r.URL.Path, rec.code)
+				if !ok {
+					slog.Warn("error response not defined in OpenAPI spec",
+						"method", r.Method, "path", r.URL.Path, "status", rec.code)
+					writeUndocumentedResponse(w, r, rec.code)
+					return
+				}
+				if schema != nil {
+					for _, violation := range spec.validateBody(schema, rec.body.Bytes()) {
+						slog.Warn("error response does not match OpenAPI schema",
+							"method", r.Method, "path", r.URL.Path, "status", rec.code, "violation", violation)
+					}
+				}
+			}
+			rec.flushTo(w)
+		})
+	}
+}
+
+// writeUndocumentedResponse replaces an undocumented error response with a 500.
+func writeUndocumentedResponse(w http.ResponseWriter, r *http.Request, code int) {
+	httpErr := NewHTTPErrorf(http.StatusInternalServerError,
+		"undocumented %d response for %s %s", code, r.Method, r.URL.Path)
+	data, _ := json.Marshal(httpErr)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(httpErr.Code)
+	_, _ = w.Write(data)
+}
+
+// bufferedResponseWriter captures a response so it can be inspected before being written.
+type bufferedResponseWriter struct {
+	header      http.Header
+	code        int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{header: make(http.Header), code: http.StatusOK}
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) WriteHeader(code int) {
+	if b.wroteHeader {
+		return
+	}
+	b.code = code
+	b.wroteHeader = true
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(p)
+}
+
+// flushTo writes the captured response to w.
+func (b *bufferedResponseWriter) flushTo(w http.ResponseWriter) {
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(b.code)
+	_, _ = w.Write(b.body.Bytes())
+}
+
+// openAPISpec is a loosely typed OpenAPI document.
+type openAPISpec struct {
+	doc map[string]any
+}
+
+// loadOpenAPISpec reads an OpenAPI document in YAML or JSON format.
+func loadOpenAPISpec(path string) (*openAPISpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	doc, ok := normalizeYAML(raw).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("document is not an object")
+	}
+	return &openAPISpec{doc: doc}, nil
+}
+
+// normalizeYAML converts YAML maps with non-string keys, such as status codes, to map[string]any.
+func normalizeYAML(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, item := range value {
+			value[k] = normalizeYAML(item)
+		}
+		return value
+	case map[any]any:
+		m := make(map[string]any, len(value))
+		for k, item := range value {
+			m[fmt.Sprint(k)] = normalizeYAML(item)
+		}
+		return m
+	case []any:
+		for i, item := range value {
+			value[i] = normalizeYAML(item)
+		}
+		return value
+	}
+	return v
+}
+
+// responseSchema finds the JSON schema for the response to method and path with the given status code.
+// It reports false if the spec has no response definition for the status code.
+// A nil schema with true means the response is defined without a JSON body.
+func (s *openAPISpec) responseSchema(method, path string, code int) (map[string]any, bool) {
+	operation := s.operation(method, path)
+	if operation == nil {
+		return nil, false
+	}
+	responses, _ := operation["responses"].(map[string]any)
+	status := strconv.Itoa(code)
+	for _, key := range []string{status, status[:1] + "XX", status[:1] + "xx", "default"} {
+		response, ok := s.resolve(responses[key]).(map[string]any)
+		if !ok {
+			continue
+		}
+		content, _ := response["content"].(map[string]any)
+		media, _ := content["application/json"].(map[string]any)
+		schema, _ := s.resolve(media["schema"]).(map[string]any)
+		return schema, true
+	}
+	return nil, false
+}
+
+// operation finds the operation object for method and path, matching templated path segments.
+func (s *openAPISpec) operation(method, path string) map[string]any {
+	paths, _ := s.doc["paths"].(map[string]any)
+	for template, item := range paths {
+		if !matchPathTemplate(template, path) {
+			continue
+		}
+		pathItem, _ := s.resolve(item).(map[string]any)
+		if operation, ok := pathItem[strings.ToLower(method)].(map[string]any); ok {
+			return operation
+		}
+	}
+	return nil
+}
+
+// matchPathTemplate reports whether path matches an OpenAPI path template such as /users/{id}.
+func matchPathTemplate(template, path string) bool {
+	templateParts := strings.Split(strings.Trim(template, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateParts) != len(pathParts) {
+		return false
+	}
+	for i, part := range templateParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if pathParts[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// resolve follows local "$ref" pointers such as #/components/schemas/Error.
+func (s *openAPISpec) resolve(v any) any {
+	for i := 0; i < 32; i++ {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return v
+		}
+		var current any = s.doc
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+			obj, _ := current.(map[string]any)
+			current = obj[part]
+		}
+		v = current
+	}
+	return v
+}
+
+// validateBody validates a JSON body against schema and returns any violations.
+func (s *openAPISpec) validateBody(schema map[string]any, body []byte) []string {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{fmt.Sprintf("body is not valid JSON: %v", err)}
+	}
+	return s.validate(schema, value, "$")
+}
+
+// validate checks value against a subset of JSON Schema: type, nullable, enum,
+// required, properties, items and allOf.
+func (s *openAPISpec) validate(schema map[string]any, value any, path string) []string {
+	var violations []string
+	for _, sub := range asSlice(schema["allOf"]) {
+		if subSchema, ok := s.resolve(sub).(map[string]any); ok {
+			violations = append(violations, s.validate(subSchema, value, path)...)
+		}
+	}
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable {
+			return violations
+		}
+	}
+	if typ, ok := schema["type"].(string); ok && !matchesSchemaType(typ, value) {
+		return append(violations, fmt.Sprintf("%s: expected %s", path, typ))
+	}
+	if enum := asSlice(schema["enum"]); enum != nil && !containsValue(enum, value) {
+		violations = append(violations, fmt.Sprintf("%s: value %v not in enum", path, value))
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		for _, field := range asSlice(schema["required"]) {
+			if name, ok := field.(string); ok {
+				if _, present := v[name]; !present {
+					violations = append(violations, fmt.Sprintf("%s: missing required property %q", path, name))
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for name, propSchema := range properties {
+			fieldValue, present := v[name]
+			if !present {
+				continue
+			}
+			if sub, ok := s.resolve(propSchema).(map[string]any); ok {
+				violations = append(violations, s.validate(sub, fieldValue, path+"."+name)...)
+			}
+		}
+	case []any:
+		if items, ok := s.resolve(schema["items"]).(map[string]any); ok {
+			for i, item := range v {
+				violations = append(violations, s.validate(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return violations
+}
+
+// matchesSchemaType reports whether a decoded JSON value has the given JSON Schema type.
+func matchesSchemaType(typ string, value any) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return true
+}
+
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if fmt.Sprint(v) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
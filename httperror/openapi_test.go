@@ -0,0 +1,131 @@
+package httperror
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testOpenAPISpec = `
+openapi: 3.0.0
+info:
+  title: test
+  version: "1"
+paths:
+  /users/{id}:
+    get:
+      responses:
+        200:
+          description: ok
+        404:
+          $ref: '#/components/responses/Error'
+        5XX:
+          $ref: '#/components/responses/Error'
+components:
+  responses:
+    Error:
+      description: error
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Error'
+  schemas:
+    Error:
+      type: object
+      required: [code, message]
+      properties:
+        code:
+          type: integer
+        message:
+          type: string
+`
+
+func writeTestOpenAPISpec(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(testOpenAPISpec), 0o600))
+	return path
+}
+
+func captureWarnings(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func serveWithOpenAPIValidation(t *testing.T, path string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	middleware := OpenAPIValidationMiddleware(writeTestOpenAPISpec(t))
+	rec := httptest.NewRecorder()
+	middleware(handler).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestOpenAPIValidationMiddleware(t *testing.T) {
+	t.Run("passes through matching error responses", func(t *testing.T) {
+		logs := captureWarnings(t)
+		rec := serveWithOpenAPIValidation(t, "/users/42", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Test", "yes")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"message":"not found"}`))
+		})
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "yes", rec.Header().Get("X-Test"))
+		assert.Equal(t, `{"code":404,"message":"not found"}`, rec.Body.String())
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("logs schema mismatches", func(t *testing.T) {
+		logs := captureWarnings(t)
+		rec := serveWithOpenAPIValidation(t, "/users/42", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"code":"503"}`))
+		})
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Contains(t, logs.String(), `missing required property \"message\"`)
+		assert.Contains(t, logs.String(), "$.code: expected integer")
+	})
+
+	t.Run("replaces undocumented error responses with 500", func(t *testing.T) {
+		logs := captureWarnings(t)
+		rec := serveWithOpenAPIValidation(t, "/users/42", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "undocumented 418 response")
+		assert.Contains(t, logs.String(), "error response not defined in OpenAPI spec")
+	})
+
+	t.Run("replaces errors for unknown paths with 500", func(t *testing.T) {
+		captureWarnings(t)
+		rec := serveWithOpenAPIValidation(t, "/orders", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("does not validate successful responses", func(t *testing.T) {
+		logs := captureWarnings(t)
+		rec := serveWithOpenAPIValidation(t, "/orders", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`ok`))
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "ok", rec.Body.String())
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("panics when spec cannot be loaded", func(t *testing.T) {
+		assert.Panics(t, func() {
+			OpenAPIValidationMiddleware(filepath.Join(t.TempDir(), "missing.yaml"))
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package httperror
+
+import "fmt"
+
+// Option configures an HTTPError created with NewWithOptions.
+type Option func(*HTTPError)
+
+// NewWithOptions creates a new HTTPError configured by opts, applied in order.
+// Without a WithCode option the status code is the package default code; see SetDefaultCode.
+func NewWithOptions(opts ...Option) *HTTPError {
+	e := &HTTPError{Code: GetDefaultCode(), Meta: make(map[string]any)}
+	applyDefaultMeta(e)
+	if autoCapture.Load() {
+		e.frames = callers(1)
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	applyDefaultMaxMessageLength(e)
+	notifyAPM(e)
+	return e
+}
+
+// WithCode sets the status code of the HTTPError.
+func WithCode(code int) Option {
+	return func(e *HTTPError) {
+		e.Code = code
+	}
+}
+
+// WithMessage sets the message of the HTTPError.
+func WithMessage(message string) Option {
+	return func(e *HTTPError) {
+		e.Message = message
+	}
+}
+
+// WithMessagef sets the message of the HTTPError to the formatted string.
+func WithMessagef(format string, args ...any) Option {
+	return func(e *HTTPError) {
+		e.Message = fmt.Sprintf(format, args...)
+	}
+}
+
+// WithMetaValue adds a metadata value to the HTTPError.
+func WithMetaValue(key string, value any) Option {
+	return func(e *HTTPError) {
+		e.Meta[key] = value
+	}
+}
+
+// WithWrapped sets the error wrapped by the HTTPError.
+func WithWrapped(err error) Option {
+	return func(e *HTTPError) {
+		e.err = err
+	}
+}
+
+// WithCapturedStack records a stack trace on the HTTPError starting at the
+// caller of NewWithOptions, regardless of SetAutoCapture.
+func WithCapturedStack() Option {
+	return func(e *HTTPError) {
+		e.frames = callers(2)
+	}
+}
@@ -0,0 +1,89 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	t.Run("defaults to 500 without options", func(t *testing.T) {
+		err := NewWithOptions()
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		assert.Equal(t, "", err.Message)
+		assert.NotNil(t, err.Meta)
+	})
+
+	t.Run("is equivalent to manual constructors", func(t *testing.T) {
+		cause := errors.New("cause")
+		err := NewWithOptions(
+			WithCode(http.StatusNotFound),
+			WithMessage("not found"),
+			WithMetaValue("id", "1"),
+			WithWrapped(cause),
+		)
+		expected := WrapErrorf(http.StatusNotFound, cause, "not found").AddMetaValue("id", "1")
+		assert.Equal(t, expected, err)
+	})
+}
+
+func TestWithCode(t *testing.T) {
+	t.Run("sets code", func(t *testing.T) {
+		err := NewWithOptions(WithCode(http.StatusConflict))
+		assert.Equal(t, http.StatusConflict, err.Code)
+	})
+}
+
+func TestWithMessage(t *testing.T) {
+	t.Run("sets message", func(t *testing.T) {
+		err := NewWithOptions(WithMessage("conflict"))
+		assert.Equal(t, "conflict", err.Message)
+	})
+}
+
+func TestWithMessagef(t *testing.T) {
+	t.Run("sets formatted message", func(t *testing.T) {
+		err := NewWithOptions(WithMessagef("user %s not found", "123"))
+		assert.Equal(t, "user 123 not found", err.Message)
+	})
+}
+
+func TestWithMetaValue(t *testing.T) {
+	t.Run("adds meta value", func(t *testing.T) {
+		err := NewWithOptions(WithMetaValue("key", "value"))
+		assert.Equal(t, "value", err.Meta["key"])
+	})
+}
+
+func TestWithWrapped(t *testing.T) {
+	t.Run("sets wrapped error", func(t *testing.T) {
+		cause := errors.New("cause")
+		err := NewWithOptions(WithWrapped(cause))
+		assert.Equal(t, cause, err.Unwrap())
+	})
+}
+
+func TestWithCapturedStack(t *testing.T) {
+	t.Run("captures stack at caller", func(t *testing.T) {
+		err := NewWithOptions(WithCapturedStack())
+		frames := err.StackTrace()
+		assert.NotEmpty(t, frames)
+		assert.True(t, strings.HasSuffix(frames[0].Function, "TestWithCapturedStack.func1"))
+	})
+
+	t.Run("auto capture starts at caller", func(t *testing.T) {
+		SetAutoCapture(true)
+		defer SetAutoCapture(false)
+		frames := NewWithOptions().StackTrace()
+		assert.NotEmpty(t, frames)
+		assert.True(t, strings.HasSuffix(frames[0].Function, "TestWithCapturedStack.func2"))
+	})
+
+	t.Run("no stack without option", func(t *testing.T) {
+		err := NewWithOptions()
+		assert.Nil(t, err.StackTrace())
+	})
+}
@@ -0,0 +1,48 @@
+package httperror
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// NewFromPanic converts a recovered panic value into an HTTPError.
+// An error with an HTTPError in its chain returns that HTTPError, any other
+// error is wrapped with a 500 and any other value becomes a 500 with the value
+// formatted as the message.
+func NewFromPanic(v any) *HTTPError {
+	switch v := v.(type) {
+	case error:
+		if httpErr, ok := AsHTTPError(v); ok {
+			return httpErr
+		}
+		return WrapError(http.StatusInternalServerError, v)
+	default:
+		return NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("%v", v))
+	}
+}
+
+// RecoverHTTPError calls fn and returns the HTTPError for any panic it raises,
+// or nil if fn returns normally.
+func RecoverHTTPError(fn func()) (result *HTTPError) {
+	defer func() {
+		if v := recover(); v != nil {
+			result = NewFromPanic(v)
+		}
+	}()
+	fn()
+	return nil
+}
+
+// RecoverAndWrap calls fn and returns its error wrapped with code, or the
+// HTTPError for any panic it raises. It returns nil if fn returns nil.
+func RecoverAndWrap(code int, fn func() error) (result *HTTPError) {
+	defer func() {
+		if v := recover(); v != nil {
+			result = NewFromPanic(v)
+		}
+	}()
+	if err := fn(); err != nil {
+		return WrapError(code, err)
+	}
+	return nil
+}
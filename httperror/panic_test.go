@@ -0,0 +1,87 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFromPanic(t *testing.T) {
+	t.Run("returns HTTPError unchanged", func(t *testing.T) {
+		err := NewHTTPError(http.StatusConflict, "conflict")
+		assert.Same(t, err, NewFromPanic(err))
+	})
+
+	t.Run("returns HTTPError from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found")
+		assert.Same(t, err, NewFromPanic(fmt.Errorf("loading user: %w", err)))
+	})
+
+	t.Run("wraps error with 500", func(t *testing.T) {
+		cause := errors.New("boom")
+		err := NewFromPanic(cause)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		assert.Equal(t, "boom", err.Message)
+		assert.True(t, errors.Is(err, cause))
+	})
+
+	t.Run("formats other values", func(t *testing.T) {
+		assert.Equal(t, "boom", NewFromPanic("boom").Message)
+		assert.Equal(t, "42", NewFromPanic(42).Message)
+		assert.Equal(t, http.StatusInternalServerError, NewFromPanic(42).Code)
+	})
+}
+
+func TestRecoverHTTPError(t *testing.T) {
+	t.Run("recovers HTTPError panic", func(t *testing.T) {
+		err := RecoverHTTPError(func() { panic(NewHTTPError(http.StatusConflict, "conflict")) })
+		assert.Equal(t, http.StatusConflict, err.Code)
+	})
+
+	t.Run("recovers error panic", func(t *testing.T) {
+		err := RecoverHTTPError(func() { panic(errors.New("boom")) })
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		assert.Equal(t, "boom", err.Message)
+	})
+
+	t.Run("recovers string panic", func(t *testing.T) {
+		err := RecoverHTTPError(func() { panic("boom") })
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		assert.Equal(t, "boom", err.Message)
+	})
+
+	t.Run("returns nil without panic", func(t *testing.T) {
+		assert.Nil(t, RecoverHTTPError(func() {}))
+	})
+}
+
+func TestRecoverAndWrap(t *testing.T) {
+	t.Run("recovers HTTPError panic", func(t *testing.T) {
+		err := RecoverAndWrap(http.StatusBadGateway, func() error { panic(NewHTTPError(http.StatusConflict, "conflict")) })
+		assert.Equal(t, http.StatusConflict, err.Code)
+	})
+
+	t.Run("recovers error panic", func(t *testing.T) {
+		err := RecoverAndWrap(http.StatusBadGateway, func() error { panic(errors.New("boom")) })
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+	})
+
+	t.Run("recovers string panic", func(t *testing.T) {
+		err := RecoverAndWrap(http.StatusBadGateway, func() error { panic("boom") })
+		assert.Equal(t, "boom", err.Message)
+	})
+
+	t.Run("wraps returned error with code", func(t *testing.T) {
+		cause := errors.New("upstream failed")
+		err := RecoverAndWrap(http.StatusBadGateway, func() error { return cause })
+		assert.Equal(t, http.StatusBadGateway, err.Code)
+		assert.True(t, errors.Is(err, cause))
+	})
+
+	t.Run("returns nil without error or panic", func(t *testing.T) {
+		assert.Nil(t, RecoverAndWrap(http.StatusBadGateway, func() error { return nil }))
+	})
+}
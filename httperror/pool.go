@@ -0,0 +1,76 @@
+package httperror
+
+import "sync"
+
+// HTTPErrorPool is a fixed-size pool of pre-allocated HTTPErrors backed by a
+// ring buffer. Unlike sync.Pool, pooled errors are not dropped by the garbage
+// collector. It is safe for concurrent use.
+type HTTPErrorPool struct {
+	mu    sync.Mutex
+	ring  []*HTTPError
+	head  int
+	count int
+}
+
+// NewHTTPErrorPool creates an HTTPErrorPool holding size pre-allocated errors.
+func NewHTTPErrorPool(size int) *HTTPErrorPool {
+	if size < 0 {
+		size = 0
+	}
+	p := &HTTPErrorPool{ring: make([]*HTTPError, size), count: size}
+	for i := range p.ring {
+		p.ring[i] = &HTTPError{Meta: make(map[string]any)}
+	}
+	return p
+}
+
+// Acquire returns an HTTPError from the pool with the given status code and
+// message, initialized like one created by NewHTTPError. When the pool is
+// empty, a new HTTPError is allocated.
+func (p *HTTPErrorPool) Acquire(code int, message string) *HTTPError {
+	if message == "" {
+		message, _ = registeredCodeText(code)
+	}
+	p.mu.Lock()
+	if p.count == 0 {
+		p.mu.Unlock()
+		return newHTTPError(code, message, nil)
+	}
+	e := p.ring[p.head]
+	p.ring[p.head] = nil
+	p.head = (p.head + 1) % len(p.ring)
+	p.count--
+	p.mu.Unlock()
+
+	e.Code = code
+	e.Message = message
+	applyDefaultMeta(e)
+	if autoCapture.Load() {
+		e.frames = callers(1)
+	}
+	applyDefaultMaxMessageLength(e)
+	notifyAPM(e)
+	return e
+}
+
+// Release resets e and returns it to the pool. It is a no-op when the pool is
+// full, in which case e is left unchanged. e must not be used after it is
+// returned to the pool.
+func (p *HTTPErrorPool) Release(e *HTTPError) {
+	if e == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.count == len(p.ring) {
+		return
+	}
+	meta := e.Meta
+	clear(meta)
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	*e = HTTPError{Meta: meta}
+	p.ring[(p.head+p.count)%len(p.ring)] = e
+	p.count++
+}
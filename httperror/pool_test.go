@@ -0,0 +1,108 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorPoolAcquire(t *testing.T) {
+	t.Run("returns pooled error with code and message", func(t *testing.T) {
+		pool := NewHTTPErrorPool(1)
+		err := pool.Acquire(http.StatusNotFound, "not found")
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		assert.Equal(t, "not found", err.Message)
+		assert.NotNil(t, err.Meta)
+	})
+
+	t.Run("falls back to NewHTTPError when empty", func(t *testing.T) {
+		pool := NewHTTPErrorPool(1)
+		first := pool.Acquire(http.StatusNotFound, "not found")
+		second := pool.Acquire(http.StatusConflict, "conflict")
+		assert.NotSame(t, first, second)
+		assert.Equal(t, http.StatusConflict, second.Code)
+
+		empty := NewHTTPErrorPool(0)
+		assert.Equal(t, http.StatusGone, empty.Acquire(http.StatusGone, "gone").Code)
+	})
+
+	t.Run("applies default meta", func(t *testing.T) {
+		SetDefaultEnvironment("staging")
+		defer SetDefaultEnvironment("")
+		pool := NewHTTPErrorPool(1)
+		assert.Equal(t, "staging", GetEnvironment(pool.Acquire(http.StatusNotFound, "not found")))
+	})
+
+	t.Run("initializes like NewHTTPError", func(t *testing.T) {
+		SetDefaultMaxMessageLength(3)
+		defer SetDefaultMaxMessageLength(0)
+		SetAutoCapture(true)
+		defer SetAutoCapture(false)
+		RegisterCode(599, "Custom Error")
+		defer UnregisterCode(599)
+		var notified []*HTTPError
+		SetAPMHook(func(e *HTTPError, attrs map[string]any) {
+			notified = append(notified, e)
+		})
+		defer ClearAPMHook()
+
+		pool := NewHTTPErrorPool(1)
+		for range 2 {
+			err := pool.Acquire(http.StatusNotFound, "abcdefgh")
+			assert.Equal(t, NewHTTPError(http.StatusNotFound, "abcdefgh").Message, err.Message)
+			assert.Equal(t, "abc...", err.Message)
+			assert.NotEmpty(t, err.StackTrace())
+			assert.Contains(t, err.StackTrace()[0].Function, "TestHTTPErrorPoolAcquire")
+			assert.Contains(t, notified, err)
+		}
+		assert.Equal(t, "Cus...", pool.Acquire(599, "").Message)
+	})
+}
+
+func TestHTTPErrorPoolRelease(t *testing.T) {
+	t.Run("reuses released errors after reset", func(t *testing.T) {
+		pool := NewHTTPErrorPool(1)
+		err := pool.Acquire(http.StatusNotFound, "not found").AddMetaValue("id", "1").WithErr(errors.New("cause"))
+		pool.Release(err)
+
+		reused := pool.Acquire(http.StatusConflict, "conflict")
+		assert.Same(t, err, reused)
+		assert.Equal(t, http.StatusConflict, reused.Code)
+		assert.Empty(t, reused.Meta)
+		assert.Nil(t, reused.Unwrap())
+	})
+
+	t.Run("is a no-op when full", func(t *testing.T) {
+		pool := NewHTTPErrorPool(1)
+		extra := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "1")
+		pool.Release(extra)
+		assert.Equal(t, http.StatusNotFound, extra.Code)
+		assert.Equal(t, "not found", extra.Message)
+		assert.Equal(t, "1", extra.Meta["id"])
+		assert.NotSame(t, extra, pool.Acquire(http.StatusNotFound, "not found"))
+	})
+
+	t.Run("ignores nil", func(t *testing.T) {
+		pool := NewHTTPErrorPool(1)
+		pool.Release(nil)
+		assert.NotNil(t, pool.Acquire(http.StatusNotFound, "not found"))
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		pool := NewHTTPErrorPool(4)
+		var wg sync.WaitGroup
+		for range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 100 {
+					pool.Release(pool.Acquire(http.StatusNotFound, "not found"))
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
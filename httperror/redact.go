@@ -0,0 +1,48 @@
+package httperror
+
+import "sync"
+
+// redactedValue replaces the values of sensitive Meta keys in redacted copies.
+const redactedValue = "[REDACTED]"
+
+var (
+	sensitiveKeysMu sync.RWMutex
+	sensitiveKeys   = make(map[string]struct{})
+)
+
+// RegisterSensitiveKey marks the given Meta keys as sensitive, so that their
+// values are replaced by Redacted and in written responses.
+func RegisterSensitiveKey(keys ...string) {
+	sensitiveKeysMu.Lock()
+	defer sensitiveKeysMu.Unlock()
+	for _, k := range keys {
+		sensitiveKeys[k] = struct{}{}
+	}
+}
+
+// UnregisterSensitiveKey removes the given Meta keys from the sensitive keys.
+func UnregisterSensitiveKey(keys ...string) {
+	sensitiveKeysMu.Lock()
+	defer sensitiveKeysMu.Unlock()
+	for _, k := range keys {
+		delete(sensitiveKeys, k)
+	}
+}
+
+// Redacted returns a clone of the HTTPError with the values of sensitive Meta
+// keys replaced by "[REDACTED]". The original HTTPError is not modified.
+// See RegisterSensitiveKey.
+func (e *HTTPError) Redacted() *HTTPError {
+	clone := e.Clone()
+	if clone == nil {
+		return nil
+	}
+	sensitiveKeysMu.RLock()
+	defer sensitiveKeysMu.RUnlock()
+	for k := range clone.Meta {
+		if _, ok := sensitiveKeys[k]; ok {
+			clone.Meta[k] = redactedValue
+		}
+	}
+	return clone
+}
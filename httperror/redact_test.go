@@ -0,0 +1,180 @@
+package httperror
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorRedacted(t *testing.T) {
+	RegisterSensitiveKey("api_key", "user_email")
+	defer UnregisterSensitiveKey("api_key", "user_email")
+
+	t.Run("redacts registered keys", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").
+			AddMetaValue("api_key", "secret").
+			AddMetaValue("user_email", "jane@example.com").
+			AddMetaValue("user_id", "123")
+		redacted := err.Redacted()
+		assert.Equal(t, map[string]any{
+			"api_key":    "[REDACTED]",
+			"user_email": "[REDACTED]",
+			"user_id":    "123",
+		}, redacted.Meta)
+		assert.Equal(t, "secret", err.Meta["api_key"])
+		assert.Equal(t, "jane@example.com", err.Meta["user_email"])
+	})
+
+	t.Run("copies meta without sensitive keys unchanged", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue("user_id", "123")
+		redacted := err.Redacted()
+		assert.NotSame(t, err, redacted)
+		assert.Equal(t, err.Meta, redacted.Meta)
+		redacted.AddMetaValue("user_id", "456")
+		assert.Equal(t, "123", err.Meta["user_id"])
+	})
+
+	t.Run("handles nil error", func(t *testing.T) {
+		var err *HTTPError
+		assert.Nil(t, err.Redacted())
+	})
+}
+
+func TestUnregisterSensitiveKey(t *testing.T) {
+	t.Run("stops redacting key", func(t *testing.T) {
+		RegisterSensitiveKey("token")
+		err := NewHTTPError(http.StatusUnauthorized, "unauthorized").AddMetaValue("token", "abc")
+		assert.Equal(t, "[REDACTED]", err.Redacted().Meta["token"])
+		UnregisterSensitiveKey("token")
+		assert.Equal(t, "abc", err.Redacted().Meta["token"])
+	})
+}
+
+func TestRegisterSensitiveKeyConcurrent(t *testing.T) {
+	err := NewHTTPError(http.StatusBadRequest, "bad request")
+	var keys []string
+	for i := range 10 {
+		key := fmt.Sprintf("secret_%d", i)
+		keys = append(keys, key)
+		err.AddMetaValue(key, "value")
+	}
+	defer UnregisterSensitiveKey(keys...)
+
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterSensitiveKey(key)
+		}()
+		go func() {
+			defer wg.Done()
+			err.Redacted()
+		}()
+	}
+	wg.Wait()
+
+	for _, key := range keys {
+		assert.Equal(t, "[REDACTED]", err.Redacted().Meta[key])
+		assert.Equal(t, "value", err.Meta[key])
+	}
+}
+
+func TestRedactedResponses(t *testing.T) {
+	RegisterSensitiveKey("api_key")
+	defer UnregisterSensitiveKey("api_key")
+	err := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue("api_key", "secret")
+
+	t.Run("WriteResponse method redacts JSON", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		err.WriteResponse(rec)
+		assert.JSONEq(t, `{"code":400,"message":"bad request","meta":{"api_key":"[REDACTED]"},"schema_version":"1"}`, rec.Body.String())
+		assert.Equal(t, "secret", err.Meta["api_key"])
+	})
+
+	t.Run("WriteXML redacts XML", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteXML(rec, err)
+		assert.Contains(t, rec.Body.String(), `<entry key="api_key">[REDACTED]</entry>`)
+		assert.NotContains(t, rec.Body.String(), "secret")
+	})
+
+	t.Run("WriteNegotiatedResponse redacts JSON and XML", func(t *testing.T) {
+		for _, accept := range []string{"application/json", "application/xml"} {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", accept)
+			WriteNegotiatedResponse(rec, req, err)
+			assert.NotContains(t, rec.Body.String(), "secret", accept)
+			assert.Contains(t, rec.Body.String(), "[REDACTED]", accept)
+		}
+	})
+
+	t.Run("HandleHTTPError redacts returned errors", func(t *testing.T) {
+		handler := HandleHTTPError(func(w http.ResponseWriter, r *http.Request) error {
+			return fmt.Errorf("handler: %w", err)
+		})
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "secret")
+		assert.Contains(t, rec.Body.String(), "[REDACTED]")
+	})
+
+	t.Run("custom formatter receives redacted error", func(t *testing.T) {
+		SetErrorFormatter(func(e *HTTPError) (int, []byte, string) {
+			return e.Code, []byte(fmt.Sprint(e.Meta["api_key"])), "text/plain"
+		})
+		defer SetErrorFormatter(nil)
+		rec := httptest.NewRecorder()
+		HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return err
+		}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, "[REDACTED]", rec.Body.String())
+	})
+
+	t.Run("middleware chain redacts returned errors", func(t *testing.T) {
+		handler := NewMiddlewareChain().WithRecovery().WithEnrichment().Build()(HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return err
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.NotContains(t, rec.Body.String(), "secret")
+		assert.Contains(t, rec.Body.String(), "[REDACTED]")
+	})
+
+	t.Run("WriteSSEError redacts event data", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		assert.NoError(t, WriteSSEError(rec, err))
+		assert.NotContains(t, rec.Body.String(), "secret")
+		assert.Contains(t, rec.Body.String(), "[REDACTED]")
+	})
+
+	t.Run("ToGraphQLError redacts extensions meta", func(t *testing.T) {
+		extensions := ToGraphQLError(err)["extensions"].(map[string]any)
+		assert.Equal(t, "[REDACTED]", extensions["meta"].(map[string]any)["api_key"])
+		assert.Equal(t, "secret", err.Meta["api_key"])
+	})
+
+	t.Run("ToCloudEvent redacts event data", func(t *testing.T) {
+		data, marshalErr := json.Marshal(ToCloudEvent(err))
+		assert.NoError(t, marshalErr)
+		assert.NotContains(t, string(data), "secret")
+		assert.Contains(t, string(data), "[REDACTED]")
+		assert.Equal(t, "secret", err.Meta["api_key"])
+	})
+
+	t.Run("negotiated WriteResponse redacts body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", "application/json")
+		assert.NoError(t, WriteResponse(rec, req, err))
+		assert.NotContains(t, rec.Body.String(), "secret")
+		assert.Contains(t, rec.Body.String(), "[REDACTED]")
+	})
+}
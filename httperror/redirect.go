@@ -0,0 +1,53 @@
+package httperror
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// redirectCodes are the status codes accepted by NewRedirect.
+var redirectCodes = map[int]bool{
+	http.StatusMovedPermanently:  true,
+	http.StatusFound:             true,
+	http.StatusSeeOther:          true,
+	http.StatusTemporaryRedirect: true,
+	http.StatusPermanentRedirect: true,
+}
+
+// NewRedirect creates a redirect HTTPError with the Location header set to location.
+// It returns an error if code is not 301, 302, 303, 307 or 308, or location is nil.
+func NewRedirect(code int, location *url.URL) (*HTTPError, error) {
+	if !redirectCodes[code] {
+		return nil, fmt.Errorf("httperror: %d is not a redirect status code", code)
+	}
+	if location == nil {
+		return nil, fmt.Errorf("httperror: redirect location is nil")
+	}
+	return newHTTPError(code, http.StatusText(code), nil, withLocation(location)), nil
+}
+
+// NewPermanentRedirect creates a 308 HTTPError redirecting to location.
+// A nil location returns nil.
+func NewPermanentRedirect(location *url.URL) *HTTPError {
+	if location == nil {
+		return nil
+	}
+	return newHTTPError(http.StatusPermanentRedirect, http.StatusText(http.StatusPermanentRedirect), nil, withLocation(location))
+}
+
+// NewTemporaryRedirect creates a 307 HTTPError redirecting to location.
+// A nil location returns nil.
+func NewTemporaryRedirect(location *url.URL) *HTTPError {
+	if location == nil {
+		return nil
+	}
+	return newHTTPError(http.StatusTemporaryRedirect, http.StatusText(http.StatusTemporaryRedirect), nil, withLocation(location))
+}
+
+// withLocation returns an Option setting the Location response header to location.
+func withLocation(location *url.URL) Option {
+	return func(e *HTTPError) {
+		e.setHeader("Location", location.String())
+	}
+}
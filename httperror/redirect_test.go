@@ -0,0 +1,78 @@
+package httperror
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRedirect(t *testing.T) {
+	location, _ := url.Parse("https://example.com/new?x=1")
+
+	t.Run("creates redirect with Location header", func(t *testing.T) {
+		err, constructErr := NewRedirect(http.StatusMovedPermanently, location)
+		assert.NoError(t, constructErr)
+		assert.Equal(t, http.StatusMovedPermanently, err.Code)
+		assert.Equal(t, "Moved Permanently", err.Message)
+		assert.Equal(t, "https://example.com/new?x=1", err.Header.Get("Location"))
+		assert.True(t, IsRedirect(err))
+	})
+
+	t.Run("accepts redirect codes", func(t *testing.T) {
+		for _, code := range []int{http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect} {
+			err, constructErr := NewRedirect(code, location)
+			assert.NoError(t, constructErr, code)
+			assert.NotEmpty(t, err.Message, code)
+		}
+	})
+
+	t.Run("rejects non-redirect codes", func(t *testing.T) {
+		for _, code := range []int{http.StatusMultipleChoices, http.StatusNotModified, http.StatusUseProxy, 306, http.StatusOK, 309, http.StatusBadRequest} {
+			err, constructErr := NewRedirect(code, location)
+			assert.Error(t, constructErr, code)
+			assert.Nil(t, err)
+		}
+	})
+
+	t.Run("rejects nil location", func(t *testing.T) {
+		_, constructErr := NewRedirect(http.StatusFound, nil)
+		assert.Error(t, constructErr)
+	})
+
+	t.Run("writes Location header in response", func(t *testing.T) {
+		err, _ := NewRedirect(http.StatusSeeOther, location)
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, err)
+		assert.Equal(t, http.StatusSeeOther, rec.Code)
+		assert.Equal(t, "https://example.com/new?x=1", rec.Header().Get("Location"))
+	})
+}
+
+func TestNewPermanentRedirect(t *testing.T) {
+	t.Run("creates 308 redirect", func(t *testing.T) {
+		location, _ := url.Parse("/moved")
+		err := NewPermanentRedirect(location)
+		assert.Equal(t, http.StatusPermanentRedirect, err.Code)
+		assert.Equal(t, "/moved", err.Header.Get("Location"))
+	})
+
+	t.Run("returns nil for nil location", func(t *testing.T) {
+		assert.Nil(t, NewPermanentRedirect(nil))
+	})
+}
+
+func TestNewTemporaryRedirect(t *testing.T) {
+	t.Run("creates 307 redirect", func(t *testing.T) {
+		location, _ := url.Parse("/elsewhere")
+		err := NewTemporaryRedirect(location)
+		assert.Equal(t, http.StatusTemporaryRedirect, err.Code)
+		assert.Equal(t, "/elsewhere", err.Header.Get("Location"))
+	})
+
+	t.Run("returns nil for nil location", func(t *testing.T) {
+		assert.Nil(t, NewTemporaryRedirect(nil))
+	})
+}
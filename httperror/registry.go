@@ -0,0 +1,25 @@
+package httperror
+
+import "sync"
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]*HTTPError)
+)
+
+// RegisterError registers e under name in the global error registry,
+// replacing any error already registered under that name.
+func RegisterError(name string, e *HTTPError) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = e
+}
+
+// LookupError returns the error registered under name. Like the sentinel
+// errors, registered errors are shared values and must not be modified.
+func LookupError(name string) (*HTTPError, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	e, ok := registry[name]
+	return e, ok
+}
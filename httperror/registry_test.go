@@ -0,0 +1,33 @@
+package httperror
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterError(t *testing.T) {
+	t.Run("registers error by name", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "user not found")
+		RegisterError("test_register_user_not_found", err)
+		got, ok := LookupError("test_register_user_not_found")
+		assert.True(t, ok)
+		assert.Same(t, err, got)
+	})
+
+	t.Run("replaces existing error", func(t *testing.T) {
+		RegisterError("test_register_replace", NewHTTPError(http.StatusNotFound, "old"))
+		RegisterError("test_register_replace", NewHTTPError(http.StatusGone, "new"))
+		got, _ := LookupError("test_register_replace")
+		assert.Equal(t, http.StatusGone, got.Code)
+	})
+}
+
+func TestLookupError(t *testing.T) {
+	t.Run("returns false for unknown name", func(t *testing.T) {
+		got, ok := LookupError("test_lookup_unknown")
+		assert.False(t, ok)
+		assert.Nil(t, got)
+	})
+}
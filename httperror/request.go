@@ -0,0 +1,35 @@
+package httperror
+
+import (
+	"net"
+	"net/http"
+)
+
+// RemoteIPMetaKey is the Meta key used to store the remote IP address of the request.
+const RemoteIPMetaKey = "remote_ip"
+
+// WithIPAddress records the remote IP address of the request. A nil ip is ignored.
+func (e *HTTPError) WithIPAddress(ip net.IP) *HTTPError {
+	if ip == nil {
+		return e
+	}
+	return e.AddMetaValue(RemoteIPMetaKey, ip.String())
+}
+
+// GetIPAddress returns the remote IP address recorded on the HTTPError in err's chain,
+// or nil if none was recorded.
+func GetIPAddress(err error) net.IP {
+	return net.ParseIP(metaString(err, RemoteIPMetaKey))
+}
+
+// NewFromRequest creates a new HTTPError recording the remote IP address of r,
+// taken from r.RemoteAddr.
+func NewFromRequest(code int, message string, r *http.Request) *HTTPError {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return newHTTPError(code, message, nil, func(e *HTTPError) {
+		e.WithIPAddress(net.ParseIP(host))
+	})
+}
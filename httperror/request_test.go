@@ -0,0 +1,59 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorWithIPAddress(t *testing.T) {
+	t.Run("stores IP address in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusForbidden, "forbidden").WithIPAddress(net.ParseIP("192.0.2.1"))
+		assert.Equal(t, "192.0.2.1", err.Meta[RemoteIPMetaKey])
+	})
+
+	t.Run("ignores nil IP", func(t *testing.T) {
+		err := NewHTTPError(http.StatusForbidden, "forbidden").WithIPAddress(nil)
+		assert.NotContains(t, err.Meta, RemoteIPMetaKey)
+	})
+}
+
+func TestGetIPAddress(t *testing.T) {
+	t.Run("returns IP address from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusForbidden, "forbidden").WithIPAddress(net.ParseIP("2001:db8::1"))
+		assert.True(t, net.ParseIP("2001:db8::1").Equal(GetIPAddress(fmt.Errorf("handler: %w", err))))
+	})
+
+	t.Run("returns nil when not set", func(t *testing.T) {
+		assert.Nil(t, GetIPAddress(NewHTTPError(http.StatusForbidden, "forbidden")))
+		assert.Nil(t, GetIPAddress(errors.New("standard error")))
+	})
+}
+
+func TestNewFromRequest(t *testing.T) {
+	t.Run("records remote IP", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = "198.51.100.7:54321"
+		err := NewFromRequest(http.StatusTooManyRequests, "slow down", r)
+		assert.Equal(t, http.StatusTooManyRequests, err.Code)
+		assert.Equal(t, "slow down", err.Message)
+		assert.Equal(t, "198.51.100.7", GetIPAddress(err).String())
+	})
+
+	t.Run("accepts remote address without port", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = "198.51.100.7"
+		assert.Equal(t, "198.51.100.7", GetIPAddress(NewFromRequest(http.StatusForbidden, "forbidden", r)).String())
+	})
+
+	t.Run("skips invalid remote address", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = "@"
+		assert.Nil(t, GetIPAddress(NewFromRequest(http.StatusForbidden, "forbidden", r)))
+	})
+}
@@ -0,0 +1,125 @@
+package httperror
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// setHeader sets a response header on the HTTPError, initializing Header if needed.
+func (e *HTTPError) setHeader(key, value string) {
+	if e.Header == nil {
+		e.Header = make(http.Header)
+	}
+	e.Header.Set(key, value)
+}
+
+// unforwardedHeaders are downstream response headers that describe the
+// downstream connection or body, or carry credentials for it, and are not forwarded.
+var unforwardedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Connection":          true,
+	"Content-Encoding":    true,
+	"Content-Length":      true,
+	"Content-Type":        true,
+	"Keep-Alive":          true,
+	"Proxy-Authenticate":  true,
+	"Proxy-Authorization": true,
+	"Set-Cookie":          true,
+	"Trailer":             true,
+	"Transfer-Encoding":   true,
+	"Upgrade":             true,
+}
+
+// applyHeaders copies the forwarded downstream headers and then the HTTPError's
+// response headers to w.
+func applyHeaders(w http.ResponseWriter, e *HTTPError) {
+	for k, v := range ResponseHeaders(e) {
+		if !unforwardedHeaders[http.CanonicalHeaderKey(k)] {
+			w.Header()[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+		}
+	}
+	for k, v := range e.Header {
+		w.Header()[k] = append([]string(nil), v...)
+	}
+}
+
+// applyDerivedHeaders sets headers derived from the error's status code and Meta
+// that are not already set.
+func applyDerivedHeaders(w http.ResponseWriter, e *HTTPError) {
+	setIfEmpty := func(key, value string) {
+		if value != "" && w.Header().Get(key) == "" {
+			w.Header().Set(key, value)
+		}
+	}
+	if e.Code == http.StatusMethodNotAllowed {
+		setIfEmpty("Allow", strings.Join(GetAllowedMethods(e), ", "))
+	}
+	if e.Code == http.StatusProxyAuthRequired {
+		setIfEmpty("Proxy-Authenticate", metaString(e, ProxyAuthenticateMetaKey))
+	}
+	setIfEmpty("traceparent", GetTraceParent(e))
+	setIfEmpty("tracestate", GetTraceState(e))
+}
+
+// responseStatus returns the status to write for an error with the given code.
+// net/http panics on status codes outside 100-999, so codes outside 100-599,
+// such as custom codes registered with RegisterCode or the 0 of an error decoded
+// without a code, are written as the package default code, or 500 if that is
+// also out of range. The body keeps the error's own code.
+func responseStatus(code int) int {
+	if code >= 100 && code <= 599 {
+		return code
+	}
+	if code = GetDefaultCode(); code >= 100 && code <= 599 {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// writeBody writes body as the response for e with the given status code and
+// Content-Type. It applies e's response headers and derived headers, sets the
+// Content-Length header and returns the error from writing the body.
+func writeBody(w http.ResponseWriter, e *HTTPError, code int, contentType string, body []byte) error {
+	applyHeaders(w, e)
+	applyDerivedHeaders(w, e)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(responseStatus(code))
+	_, err := w.Write(body)
+	return err
+}
+
+// writeJSON writes e to w as a JSON response, applying its response headers.
+// The body is encoded once, with sensitive Meta values redacted, and its length
+// is used for the Content-Length header.
+func writeJSON(w http.ResponseWriter, e *HTTPError) {
+	data, err := json.Marshal(e.Redacted())
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	_ = writeBody(w, e, e.Code, contentTypeJSON, data)
+}
+
+// WriteResponse writes the HTTPError to w as a JSON response, setting the
+// Content-Type header and using the error's status code. Sensitive Meta values
+// are redacted; see RegisterSensitiveKey.
+func (e *HTTPError) WriteResponse(w http.ResponseWriter) {
+	writeJSON(w, e)
+}
+
+// WriteJSONResponse writes err to w as a JSON response.
+// Errors that are not HTTPErrors are written with the default code. A nil err writes nothing.
+func WriteJSONResponse(w http.ResponseWriter, err error) {
+	if httpErr := ToHTTPError(err); httpErr != nil {
+		httpErr.WriteResponse(w)
+	}
+}
+
+// WriteXMLResponse writes err to w as an XML response.
+// Errors that are not HTTPErrors are written with the default code. A nil err writes nothing.
+func WriteXMLResponse(w http.ResponseWriter, err error) {
+	WriteXML(w, err)
+}
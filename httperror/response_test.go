@@ -0,0 +1,120 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorWriteResponse(t *testing.T) {
+	t.Run("writes 4xx JSON response", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("user_id", "123").WriteResponse(rec)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"code":404,"message":"not found","meta":{"user_id":"123"},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("writes 5xx JSON response", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewHTTPError(http.StatusServiceUnavailable, "unavailable").WriteResponse(rec)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.JSONEq(t, `{"code":503,"message":"unavailable","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("applies response headers", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewHTTPError(http.StatusForbidden, "forbidden").WithCORSHeaders("*").WriteResponse(rec)
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestWriteJSONResponse(t *testing.T) {
+	t.Run("writes HTTPError", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, NewHTTPError(http.StatusBadRequest, "bad request"))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"code":400,"message":"bad request","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("writes plain error as 500", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, errors.New("boom"))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"code":500,"message":"boom","meta":{},"schema_version":"1"}`, rec.Body.String())
+	})
+
+	t.Run("writes nothing for nil error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, nil)
+		assert.Empty(t, rec.Body.String())
+		assert.Empty(t, rec.Header().Get("Content-Type"))
+	})
+}
+
+func TestWriteXMLResponse(t *testing.T) {
+	t.Run("writes HTTPError as XML", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteXMLResponse(rec, NewHTTPError(http.StatusConflict, "conflict"))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, "application/xml; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "<error><code>409</code><message>conflict</message>")
+	})
+}
+
+func TestWriteJSONResponseContentLength(t *testing.T) {
+	t.Run("sets Content-Length to body length", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request")
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, err)
+		n, _ := err.Len()
+		assert.Equal(t, strconv.Itoa(n), rec.Header().Get("Content-Length"))
+		assert.Equal(t, n, rec.Body.Len())
+	})
+}
+
+func TestResponseStatus(t *testing.T) {
+	t.Run("writes out-of-range codes with the default code", func(t *testing.T) {
+		writers := map[string]func(w http.ResponseWriter, e *HTTPError){
+			"WriteResponse":     func(w http.ResponseWriter, e *HTTPError) { e.WriteResponse(w) },
+			"WriteJSONResponse": func(w http.ResponseWriter, e *HTTPError) { WriteJSONResponse(w, e) },
+			"WriteXML":          func(w http.ResponseWriter, e *HTTPError) { WriteXML(w, e) },
+			"WriteNegotiatedResponse": func(w http.ResponseWriter, e *HTTPError) {
+				WriteNegotiatedResponse(w, httptest.NewRequest(http.MethodGet, "/", nil), e)
+			},
+			"negotiated WriteResponse": func(w http.ResponseWriter, e *HTTPError) {
+				_ = WriteResponse(w, httptest.NewRequest(http.MethodGet, "/", nil), e)
+			},
+			"HandleHTTPError": func(w http.ResponseWriter, e *HTTPError) {
+				HandleHTTPError(func(http.ResponseWriter, *http.Request) error { return e }).
+					ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			},
+		}
+		for name, write := range writers {
+			for _, code := range []int{0, 10001} {
+				rec := httptest.NewRecorder()
+				assert.NotPanics(t, func() { write(rec, &HTTPError{Code: code, Message: "custom", Meta: map[string]any{}}) }, name)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code, name)
+				assert.Contains(t, rec.Body.String(), strconv.Itoa(code), name)
+			}
+		}
+	})
+
+	t.Run("uses the package default code", func(t *testing.T) {
+		SetDefaultCode(http.StatusBadGateway)
+		defer SetDefaultCode(http.StatusInternalServerError)
+		assert.Equal(t, http.StatusBadGateway, responseStatus(0))
+		assert.Equal(t, http.StatusNotFound, responseStatus(http.StatusNotFound))
+	})
+
+	t.Run("falls back to 500 for an out-of-range default code", func(t *testing.T) {
+		SetDefaultCode(10001)
+		defer SetDefaultCode(http.StatusInternalServerError)
+		assert.Equal(t, http.StatusInternalServerError, responseStatus(10001))
+	})
+}
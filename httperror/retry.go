@@ -0,0 +1,62 @@
+package httperror
+
+import "errors"
+
+// RetryCountMetaKey is the Meta key used to store the number of retry attempts.
+const RetryCountMetaKey = "_retry_count"
+
+// metaInt returns the integer stored under key in the first HTTPError found in
+// err's chain. Numbers decoded from JSON or YAML are accepted.
+func metaInt(err error, key string) (int, bool) {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return 0, false
+	}
+	switch v := httpErr.Meta[key].(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// WithRetryCount records the number of times the failed operation was retried.
+func (e *HTTPError) WithRetryCount(n int) *HTTPError {
+	return e.AddMetaValue(RetryCountMetaKey, n)
+}
+
+// IncrementRetryCount increments the retry count recorded on e, starting at 1
+// if none was recorded, and returns e. A nil e returns nil.
+func IncrementRetryCount(e *HTTPError) *HTTPError {
+	if e == nil {
+		return nil
+	}
+	n, _ := metaInt(e, RetryCountMetaKey)
+	return e.WithRetryCount(n + 1)
+}
+
+// GetRetryCount returns the retry count recorded on the HTTPError in err's chain, or 0.
+func GetRetryCount(err error) int {
+	n, _ := metaInt(err, RetryCountMetaKey)
+	return n
+}
+
+// IncrementRetry is an alias for IncrementRetryCount.
+func IncrementRetry(e *HTTPError) *HTTPError {
+	return IncrementRetryCount(e)
+}
+
+// RetryCount is an alias for GetRetryCount.
+func RetryCount(err error) int {
+	return GetRetryCount(err)
+}
+
+// MaxRetriesExceeded reports whether the retry count recorded on the HTTPError in
+// err's chain has reached maxRetries.
+func MaxRetriesExceeded(err error, maxRetries int) bool {
+	n, ok := metaInt(err, RetryCountMetaKey)
+	return ok && n >= maxRetries
+}
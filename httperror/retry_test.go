@@ -0,0 +1,86 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorWithRetryCount(t *testing.T) {
+	t.Run("stores retry count in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable").WithRetryCount(3)
+		assert.Equal(t, 3, err.Meta[RetryCountMetaKey])
+	})
+}
+
+func TestIncrementRetryCount(t *testing.T) {
+	t.Run("initializes to 1", func(t *testing.T) {
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable")
+		assert.Same(t, err, IncrementRetryCount(err))
+		assert.Equal(t, 1, GetRetryCount(err))
+	})
+
+	t.Run("increments existing count", func(t *testing.T) {
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable").WithRetryCount(2)
+		IncrementRetryCount(IncrementRetryCount(err))
+		assert.Equal(t, 4, GetRetryCount(err))
+	})
+
+	t.Run("nil error returns nil", func(t *testing.T) {
+		assert.Nil(t, IncrementRetryCount(nil))
+		assert.Nil(t, IncrementRetry(nil))
+	})
+
+	t.Run("increments count decoded from JSON", func(t *testing.T) {
+		err, decodeErr := NewHTTPErrorFromJSON([]byte(`{"code":503,"message":"unavailable","meta":{"_retry_count":2}}`))
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, 3, GetRetryCount(IncrementRetryCount(err)))
+	})
+}
+
+func TestGetRetryCount(t *testing.T) {
+	t.Run("returns retry count from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable").WithRetryCount(3)
+		assert.Equal(t, 3, GetRetryCount(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns 0 when not set", func(t *testing.T) {
+		assert.Equal(t, 0, GetRetryCount(NewHTTPError(http.StatusServiceUnavailable, "unavailable")))
+		assert.Equal(t, 0, GetRetryCount(errors.New("standard error")))
+	})
+}
+
+func TestRetryCount(t *testing.T) {
+	t.Run("tracks count across increments", func(t *testing.T) {
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable").WithRetryCount(1)
+		for range 3 {
+			IncrementRetry(err)
+		}
+		assert.Equal(t, 4, RetryCount(err))
+		assert.Equal(t, 4, err.Meta["_retry_count"])
+	})
+
+	t.Run("returns 0 for non-HTTPError and nil", func(t *testing.T) {
+		assert.Equal(t, 0, RetryCount(errors.New("standard error")))
+		assert.Equal(t, 0, RetryCount(nil))
+	})
+}
+
+func TestMaxRetriesExceeded(t *testing.T) {
+	t.Run("compares count with max", func(t *testing.T) {
+		err := NewHTTPError(http.StatusServiceUnavailable, "unavailable").WithRetryCount(2)
+		assert.False(t, MaxRetriesExceeded(err, 3))
+		IncrementRetry(err)
+		assert.True(t, MaxRetriesExceeded(err, 3))
+		assert.True(t, MaxRetriesExceeded(fmt.Errorf("handler: %w", err), 2))
+	})
+
+	t.Run("false without a recorded count", func(t *testing.T) {
+		assert.False(t, MaxRetriesExceeded(NewHTTPError(http.StatusServiceUnavailable, "unavailable"), 1))
+		assert.False(t, MaxRetriesExceeded(errors.New("standard error"), 1))
+		assert.False(t, MaxRetriesExceeded(nil, 1))
+	})
+}
@@ -0,0 +1,36 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Sentinel errors for use with errors.Is. Each has an empty message, so it
+// matches any HTTPError with the same status code. Sentinels are shared
+// values and must not be modified.
+var (
+	ErrBadRequest           = NewHTTPError(http.StatusBadRequest, "")
+	ErrUnauthorized         = NewHTTPError(http.StatusUnauthorized, "")
+	ErrForbidden            = NewHTTPError(http.StatusForbidden, "")
+	ErrNotFound             = NewHTTPError(http.StatusNotFound, "")
+	ErrConflict             = NewHTTPError(http.StatusConflict, "")
+	ErrGone                 = NewHTTPError(http.StatusGone, "")
+	ErrUnsupportedMediaType = NewHTTPError(http.StatusUnsupportedMediaType, "")
+	ErrUnprocessableEntity  = NewHTTPError(http.StatusUnprocessableEntity, "")
+	ErrTooManyRequests      = NewHTTPError(http.StatusTooManyRequests, "")
+	ErrInternalServerError  = NewHTTPError(http.StatusInternalServerError, "")
+	ErrNotImplemented       = NewHTTPError(http.StatusNotImplemented, "")
+	ErrBadGateway           = NewHTTPError(http.StatusBadGateway, "")
+	ErrServiceUnavailable   = NewHTTPError(http.StatusServiceUnavailable, "")
+	ErrGatewayTimeout       = NewHTTPError(http.StatusGatewayTimeout, "")
+)
+
+// IsHTTPErrorIn reports whether err matches any of targets, as reported by errors.Is.
+func IsHTTPErrorIn(err error, targets ...*HTTPError) bool {
+	for _, target := range targets {
+		if target != nil && errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentinels(t *testing.T) {
+	t.Run("matches wrapped errors with the same code", func(t *testing.T) {
+		someErr := errors.New("some error")
+		assert.True(t, errors.Is(WrapError(http.StatusNotFound, someErr), ErrNotFound))
+		assert.False(t, errors.Is(WrapError(http.StatusInternalServerError, someErr), ErrNotFound))
+	})
+
+	t.Run("matches through fmt.Errorf chains", func(t *testing.T) {
+		err := fmt.Errorf("loading user: %w", NewHTTPError(http.StatusNotFound, "user not found"))
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("each sentinel has its status code", func(t *testing.T) {
+		sentinels := map[*HTTPError]int{
+			ErrBadRequest:           http.StatusBadRequest,
+			ErrUnauthorized:         http.StatusUnauthorized,
+			ErrForbidden:            http.StatusForbidden,
+			ErrNotFound:             http.StatusNotFound,
+			ErrConflict:             http.StatusConflict,
+			ErrGone:                 http.StatusGone,
+			ErrUnsupportedMediaType: http.StatusUnsupportedMediaType,
+			ErrUnprocessableEntity:  http.StatusUnprocessableEntity,
+			ErrTooManyRequests:      http.StatusTooManyRequests,
+			ErrInternalServerError:  http.StatusInternalServerError,
+			ErrNotImplemented:       http.StatusNotImplemented,
+			ErrBadGateway:           http.StatusBadGateway,
+			ErrServiceUnavailable:   http.StatusServiceUnavailable,
+			ErrGatewayTimeout:       http.StatusGatewayTimeout,
+		}
+		for sentinel, code := range sentinels {
+			assert.Equal(t, code, sentinel.Code)
+			assert.Empty(t, sentinel.Message)
+			assert.True(t, errors.Is(NewHTTPError(code, "message"), sentinel))
+		}
+	})
+}
+
+func TestIsHTTPErrorIn(t *testing.T) {
+	t.Run("matches any target", func(t *testing.T) {
+		err := fmt.Errorf("loading user: %w", NewHTTPError(http.StatusGone, "user deleted"))
+		assert.True(t, IsHTTPErrorIn(err, ErrNotFound, ErrGone))
+	})
+
+	t.Run("returns false when no target matches", func(t *testing.T) {
+		err := NewHTTPError(http.StatusConflict, "conflict")
+		assert.False(t, IsHTTPErrorIn(err, ErrNotFound, ErrGone))
+		assert.False(t, IsHTTPErrorIn(err))
+	})
+
+	t.Run("returns false for nil and plain errors", func(t *testing.T) {
+		assert.False(t, IsHTTPErrorIn(nil, ErrNotFound))
+		assert.False(t, IsHTTPErrorIn(errors.New("plain"), ErrNotFound))
+	})
+}
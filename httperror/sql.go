@@ -0,0 +1,51 @@
+package httperror
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+)
+
+// DatabaseQueryMetaKey is the Meta key used to store the failing SQL query.
+const DatabaseQueryMetaKey = "db_query"
+
+// SQLErrorMapper maps database/sql errors to HTTPErrors.
+type SQLErrorMapper struct{}
+
+// Map converts a database/sql error to an HTTPError.
+// sql.ErrNoRows becomes a 404, sql.ErrConnDone a 503, and sql.ErrTxDone or
+// any other error a 500. The 500 is deliberately hard-coded rather than the
+// package default code, since an unknown database error is a server fault.
+func (SQLErrorMapper) Map(err error) *HTTPError {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return WrapError(http.StatusNotFound, err)
+	case errors.Is(err, sql.ErrConnDone):
+		return WrapError(http.StatusServiceUnavailable, err)
+	}
+	return WrapError(http.StatusInternalServerError, err)
+}
+
+// RegisterSQLMappings adds the database/sql error mappings to an existing ErrorMapper.
+func RegisterSQLMappings(m *ErrorMapper) {
+	m.Register(sql.ErrNoRows, http.StatusNotFound).
+		Register(sql.ErrConnDone, http.StatusServiceUnavailable).
+		Register(sql.ErrTxDone, http.StatusInternalServerError)
+}
+
+// WithDatabaseQuery records the SQL query that failed. Because queries may
+// contain PII, it is a no-op unless EnableSensitiveMeta has been called.
+func (e *HTTPError) WithDatabaseQuery(query string) *HTTPError {
+	if !sensitiveMeta.Load() {
+		return e
+	}
+	return e.AddMetaValue(DatabaseQueryMetaKey, query)
+}
+
+// GetDatabaseQuery returns the SQL query recorded on the HTTPError in err's chain.
+func GetDatabaseQuery(err error) string {
+	return metaString(err, DatabaseQueryMetaKey)
+}
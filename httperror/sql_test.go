@@ -0,0 +1,75 @@
+package httperror
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLErrorMapper(t *testing.T) {
+	mapper := SQLErrorMapper{}
+
+	t.Run("maps ErrNoRows to 404", func(t *testing.T) {
+		httpErr := mapper.Map(fmt.Errorf("find user: %w", sql.ErrNoRows))
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+		assert.True(t, errors.Is(httpErr, sql.ErrNoRows))
+	})
+
+	t.Run("maps ErrConnDone to 503", func(t *testing.T) {
+		assert.Equal(t, http.StatusServiceUnavailable, mapper.Map(sql.ErrConnDone).Code)
+	})
+
+	t.Run("maps ErrTxDone to 500", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, mapper.Map(sql.ErrTxDone).Code)
+	})
+
+	t.Run("maps other errors to 500", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, mapper.Map(errors.New("syntax error")).Code)
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, mapper.Map(nil))
+	})
+}
+
+func TestRegisterSQLMappings(t *testing.T) {
+	t.Run("adds sql mappings to mapper", func(t *testing.T) {
+		mapper := NewErrorMapper()
+		RegisterSQLMappings(mapper)
+		assert.Equal(t, http.StatusNotFound, mapper.Map(sql.ErrNoRows).Code)
+		assert.Equal(t, http.StatusServiceUnavailable, mapper.Map(sql.ErrConnDone).Code)
+		assert.Equal(t, http.StatusInternalServerError, mapper.Map(sql.ErrTxDone).Code)
+	})
+}
+
+func TestHTTPErrorWithDatabaseQuery(t *testing.T) {
+	t.Run("is a no-op by default", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "query failed").WithDatabaseQuery("SELECT 1")
+		assert.NotContains(t, err.Meta, DatabaseQueryMetaKey)
+	})
+
+	t.Run("stores query when sensitive meta is enabled", func(t *testing.T) {
+		EnableSensitiveMeta()
+		defer DisableSensitiveMeta()
+		err := NewHTTPError(http.StatusInternalServerError, "query failed").WithDatabaseQuery("SELECT 1")
+		assert.Equal(t, "SELECT 1", err.Meta[DatabaseQueryMetaKey])
+	})
+}
+
+func TestGetDatabaseQuery(t *testing.T) {
+	t.Run("returns query from wrapped error", func(t *testing.T) {
+		EnableSensitiveMeta()
+		defer DisableSensitiveMeta()
+		err := NewHTTPError(http.StatusInternalServerError, "query failed").WithDatabaseQuery("SELECT 1")
+		assert.Equal(t, "SELECT 1", GetDatabaseQuery(fmt.Errorf("repo: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", GetDatabaseQuery(NewHTTPError(http.StatusInternalServerError, "query failed")))
+		assert.Equal(t, "", GetDatabaseQuery(errors.New("standard error")))
+	})
+}
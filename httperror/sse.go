@@ -0,0 +1,41 @@
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// contentTypeEventStream is the Content-Type of a Server-Sent Events stream.
+const contentTypeEventStream = "text/event-stream"
+
+// WriteSSEError writes e to w as a Server-Sent Events "error" event with the
+// JSON-encoded error as its data; sensitive Meta values are redacted. The
+// Content-Type is set to text/event-stream if not already set; the status code
+// of the stream is left unchanged.
+func WriteSSEError(w http.ResponseWriter, e *HTTPError) error {
+	data, err := json.Marshal(e.Redacted())
+	if err != nil {
+		return err
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", contentTypeEventStream)
+	}
+	_, err = fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+	return err
+}
+
+// FlushSSEError writes e like WriteSSEError and flushes it to the client.
+// w must also be an http.ResponseWriter.
+func FlushSSEError(w http.Flusher, e *HTTPError) error {
+	rw, ok := w.(http.ResponseWriter)
+	if !ok {
+		return errors.New("httperror: FlushSSEError requires an http.ResponseWriter")
+	}
+	if err := WriteSSEError(rw, e); err != nil {
+		return err
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,64 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (failingResponseWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+type flushOnly struct{}
+
+func (flushOnly) Flush() {}
+
+func TestWriteSSEError(t *testing.T) {
+	t.Run("writes error event", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		err := WriteSSEError(rec, NewHTTPError(http.StatusNotFound, "not found"))
+		assert.NoError(t, err)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "event: error\ndata: {\"code\":404,\"message\":\"not found\",\"meta\":{},\"schema_version\":\"1\"}\n\n", rec.Body.String())
+	})
+
+	t.Run("keeps existing Content-Type", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		assert.NoError(t, WriteSSEError(rec, NewHTTPError(http.StatusNotFound, "not found")))
+		assert.Equal(t, "text/event-stream; charset=utf-8", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("returns encoding error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("ch", make(chan int))
+		assert.Error(t, WriteSSEError(rec, e))
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("returns write error", func(t *testing.T) {
+		w := failingResponseWriter{httptest.NewRecorder()}
+		assert.EqualError(t, WriteSSEError(w, NewHTTPError(http.StatusNotFound, "not found")), "write failed")
+	})
+}
+
+func TestFlushSSEError(t *testing.T) {
+	t.Run("writes and flushes error event", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		assert.NoError(t, FlushSSEError(rec, NewHTTPError(http.StatusNotFound, "not found")))
+		assert.True(t, rec.Flushed)
+		assert.Contains(t, rec.Body.String(), "event: error\n")
+	})
+
+	t.Run("requires a ResponseWriter", func(t *testing.T) {
+		assert.Error(t, FlushSSEError(flushOnly{}, NewHTTPError(http.StatusNotFound, "not found")))
+	})
+}
@@ -0,0 +1,165 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// FileMetaKey is the Meta key used to store the source file where the error was created.
+	FileMetaKey = "file"
+	// LineMetaKey is the Meta key used to store the source line where the error was created.
+	LineMetaKey = "line"
+)
+
+// maxStackDepth is the maximum number of frames captured for a stack trace.
+const maxStackDepth = 32
+
+// autoCapture controls whether constructors capture a stack trace.
+var autoCapture atomic.Bool
+
+// SetAutoCapture enables or disables automatic stack trace capture when
+// errors are created with NewHTTPError, WrapError and their variants.
+func SetAutoCapture(enabled bool) {
+	autoCapture.Store(enabled)
+}
+
+// callers returns the program counters of the stack, skipping the given number
+// of frames above the caller of callers.
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+// CaptureStack records the current stack trace on the HTTPError.
+// A skip of 0 starts the trace at the caller of CaptureStack.
+func (e *HTTPError) CaptureStack(skip int) *HTTPError {
+	e.frames = callers(skip + 1)
+	return e
+}
+
+// StackTrace returns the captured stack trace, or nil if none was captured.
+// Frames are resolved lazily when StackTrace is called.
+func (e *HTTPError) StackTrace() []runtime.Frame {
+	if len(e.frames) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(e.frames)
+	var stack []runtime.Frame
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// StackTraceString returns the captured stack trace formatted one frame per
+// function and file:line pair, or an empty string if none was captured.
+func (e *HTTPError) StackTraceString() string {
+	var sb strings.Builder
+	for _, frame := range e.StackTrace() {
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+	}
+	return sb.String()
+}
+
+// WithFile records the source file where the error was created.
+func (e *HTTPError) WithFile(file string) *HTTPError {
+	return e.AddMetaValue(FileMetaKey, file)
+}
+
+// WithLine records the source line where the error was created.
+func (e *HTTPError) WithLine(line int) *HTTPError {
+	return e.AddMetaValue(LineMetaKey, line)
+}
+
+// WithFileLine records the source file and line where the error was created.
+func (e *HTTPError) WithFileLine(file string, line int) *HTTPError {
+	return e.WithFile(file).WithLine(line)
+}
+
+// CaptureCaller records the file and line of a single caller on e, which is
+// cheaper than CaptureStack. A skip of 0 records the caller of CaptureCaller.
+func CaptureCaller(e *HTTPError, skip int) *HTTPError {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return e
+	}
+	return e.WithFileLine(file, line)
+}
+
+// packagePath is the import path of this package, used to filter its frames from clean stacks.
+var packagePath = reflect.TypeOf(HTTPError{}).PkgPath()
+
+// stdlibSrcDir is the directory holding the standard library sources the binary
+// was built from, derived from the file of a known standard library function.
+// It is empty if the directory cannot be determined, such as in binaries built
+// with -trimpath, in which case only runtime and internal frames are recognized.
+var stdlibSrcDir = func() string {
+	pc := reflect.ValueOf(strings.Cut).Pointer()
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+	file, _ := fn.FileLine(pc)
+	const suffix = "strings/strings.go"
+	if !strings.HasSuffix(file, "/"+suffix) {
+		return ""
+	}
+	return strings.TrimSuffix(file, suffix)
+}()
+
+// isInternalFrame reports whether the frame of function in file belongs to this
+// package, excluding its tests, or to the standard library.
+func isInternalFrame(function, file string) bool {
+	if strings.HasPrefix(function, packagePath+".") && !strings.HasSuffix(file, "_test.go") {
+		return true
+	}
+	if strings.HasPrefix(function, "runtime.") || strings.HasPrefix(function, "internal/") {
+		return true
+	}
+	return stdlibSrcDir != "" && strings.HasPrefix(file, stdlibSrcDir)
+}
+
+// CaptureCleanStack records the current stack trace on e, leaving out frames from
+// this package and the standard library so only application code remains.
+// A skip of 0 starts the trace at the caller of CaptureCleanStack.
+func CaptureCleanStack(e *HTTPError, skip int) *HTTPError {
+	var clean []uintptr
+	for _, pc := range callers(skip + 1) {
+		fn := runtime.FuncForPC(pc - 1)
+		if fn == nil {
+			continue
+		}
+		file, _ := fn.FileLine(pc - 1)
+		if !isInternalFrame(fn.Name(), file) {
+			clean = append(clean, pc)
+		}
+	}
+	e.frames = clean
+	return e
+}
+
+// GetCleanStack returns the function names of the application frames in the stack
+// trace of the HTTPError in err's chain, or nil if none was captured.
+func GetCleanStack(err error) []string {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return nil
+	}
+	var names []string
+	for _, frame := range httpErr.StackTrace() {
+		if !isInternalFrame(frame.Function, frame.File) {
+			names = append(names, frame.Function)
+		}
+	}
+	return names
+}
@@ -0,0 +1,162 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func captureStackFromHelper() *HTTPError {
+	return NewHTTPError(http.StatusInternalServerError, "boom").CaptureStack(1)
+}
+
+func captureCallerFromHelper() *HTTPError {
+	return CaptureCaller(NewHTTPError(http.StatusInternalServerError, "boom"), 1)
+}
+
+func TestHTTPErrorCaptureStack(t *testing.T) {
+	t.Run("captures calling function", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "boom").CaptureStack(0)
+		frames := err.StackTrace()
+		assert.NotEmpty(t, frames)
+		assert.Contains(t, frames[0].Function, "TestHTTPErrorCaptureStack")
+		assert.Contains(t, err.StackTraceString(), "TestHTTPErrorCaptureStack")
+	})
+
+	t.Run("skips frames", func(t *testing.T) {
+		frames := captureStackFromHelper().StackTrace()
+		assert.NotContains(t, frames[0].Function, "captureStackFromHelper")
+		assert.Contains(t, frames[0].Function, "TestHTTPErrorCaptureStack")
+	})
+
+	t.Run("returns nothing without capture", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "boom")
+		assert.Nil(t, err.StackTrace())
+		assert.Equal(t, "", err.StackTraceString())
+	})
+}
+
+func TestSetAutoCapture(t *testing.T) {
+	t.Run("captures stack in constructors when enabled", func(t *testing.T) {
+		SetAutoCapture(true)
+		defer SetAutoCapture(false)
+
+		errs := []*HTTPError{
+			NewHTTPError(http.StatusBadRequest, "bad request"),
+			NewHTTPErrorf(http.StatusBadRequest, "bad %s", "request"),
+			WrapError(http.StatusBadRequest, errors.New("standard error")),
+			WrapErrorf(http.StatusBadRequest, errors.New("standard error"), "bad"),
+		}
+		for _, err := range errs {
+			frames := err.StackTrace()
+			assert.NotEmpty(t, frames)
+			assert.Contains(t, frames[0].Function, "TestSetAutoCapture")
+		}
+	})
+
+	t.Run("does not capture stack when disabled", func(t *testing.T) {
+		SetAutoCapture(false)
+		err := NewHTTPError(http.StatusBadRequest, "bad request")
+		assert.Nil(t, err.frames)
+		assert.Nil(t, WrapError(http.StatusBadRequest, errors.New("standard error")).frames)
+	})
+}
+
+func TestHTTPErrorWithFile(t *testing.T) {
+	t.Run("stores file in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "boom").WithFile("handler.go")
+		assert.Equal(t, "handler.go", err.Meta[FileMetaKey])
+	})
+}
+
+func TestHTTPErrorWithLine(t *testing.T) {
+	t.Run("stores line in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "boom").WithLine(42)
+		assert.Equal(t, 42, err.Meta[LineMetaKey])
+	})
+}
+
+func TestHTTPErrorWithFileLine(t *testing.T) {
+	t.Run("stores file and line in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "boom").WithFileLine("handler.go", 42)
+		assert.Equal(t, "handler.go", err.Meta[FileMetaKey])
+		assert.Equal(t, 42, err.Meta[LineMetaKey])
+	})
+}
+
+func TestCaptureCaller(t *testing.T) {
+	t.Run("records calling file and line", func(t *testing.T) {
+		_, file, line, _ := runtime.Caller(0)
+		err := CaptureCaller(NewHTTPError(http.StatusInternalServerError, "boom"), 0)
+		assert.Equal(t, file, err.Meta[FileMetaKey])
+		assert.Equal(t, line+1, err.Meta[LineMetaKey])
+	})
+
+	t.Run("skips frames", func(t *testing.T) {
+		_, file, line, _ := runtime.Caller(0)
+		err := captureCallerFromHelper()
+		assert.Equal(t, file, err.Meta[FileMetaKey])
+		assert.Equal(t, line+1, err.Meta[LineMetaKey])
+	})
+}
+
+func captureCleanStackFromHelper() *HTTPError {
+	return CaptureCleanStack(NewHTTPError(http.StatusInternalServerError, "boom"), 0)
+}
+
+func TestCaptureCleanStack(t *testing.T) {
+	t.Run("keeps only application frames", func(t *testing.T) {
+		err := captureCleanStackFromHelper()
+		stack := GetCleanStack(err)
+		assert.NotEmpty(t, stack)
+		assert.Contains(t, stack[0], "captureCleanStackFromHelper")
+		assert.Contains(t, stack[1], "TestCaptureCleanStack")
+		for _, name := range stack {
+			assert.NotContains(t, name, "httperror.CaptureCleanStack")
+			assert.False(t, strings.HasPrefix(name, "runtime."), name)
+			assert.False(t, strings.HasPrefix(name, "testing."), name)
+		}
+		assert.Len(t, err.StackTrace(), len(stack))
+	})
+
+	t.Run("skips frames", func(t *testing.T) {
+		stack := GetCleanStack(CaptureCleanStack(NewHTTPError(http.StatusInternalServerError, "boom"), 1))
+		for _, name := range stack {
+			assert.NotContains(t, name, "TestCaptureCleanStack")
+		}
+	})
+}
+
+func TestGetCleanStack(t *testing.T) {
+	t.Run("filters raw stack from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusInternalServerError, "boom").CaptureStack(0)
+		stack := GetCleanStack(fmt.Errorf("handler: %w", err))
+		assert.NotEmpty(t, stack)
+		assert.Contains(t, stack[0], "TestGetCleanStack")
+		assert.Less(t, len(stack), len(err.StackTrace()))
+	})
+
+	t.Run("returns nil without stack", func(t *testing.T) {
+		assert.Nil(t, GetCleanStack(NewHTTPError(http.StatusInternalServerError, "boom")))
+		assert.Nil(t, GetCleanStack(errors.New("standard error")))
+	})
+}
+
+func TestIsInternalFrame(t *testing.T) {
+	assert.NotEmpty(t, stdlibSrcDir)
+	assert.True(t, isInternalFrame("runtime.goexit", stdlibSrcDir+"runtime/asm_amd64.s"))
+	assert.True(t, isInternalFrame("net/http.HandlerFunc.ServeHTTP", stdlibSrcDir+"net/http/server.go"))
+	assert.True(t, isInternalFrame("testing.tRunner", stdlibSrcDir+"testing/testing.go"))
+	assert.True(t, isInternalFrame(packagePath+".NewHTTPError", "/src/httperror/httperror.go"))
+	assert.False(t, isInternalFrame(packagePath+".TestX", "/src/httperror/x_test.go"))
+	assert.False(t, isInternalFrame(packagePath+"/testutil.AssertStatus", "/src/httperror/testutil/assert.go"))
+	assert.False(t, isInternalFrame("main.main", "/app/main.go"))
+	assert.False(t, isInternalFrame("myapp/handlers.GetUser", "/app/handlers/user.go"))
+	assert.False(t, isInternalFrame("myservice.Run", "/app/service.go"))
+	assert.False(t, isInternalFrame("example.com/app/handlers.GetUser", "/app/handlers/user.go"))
+}
@@ -0,0 +1,50 @@
+package httperror
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+)
+
+// stdErrorMapper maps common standard library errors to HTTP status codes.
+var stdErrorMapper = NewErrorMapper().
+	Register(os.ErrNotExist, http.StatusNotFound).
+	Register(os.ErrPermission, http.StatusForbidden).
+	Register(os.ErrDeadlineExceeded, http.StatusRequestTimeout).
+	Register(io.ErrUnexpectedEOF, http.StatusBadRequest)
+
+// ConvertStdError converts common standard library errors to an HTTPError.
+// os.ErrNotExist becomes a 404, os.ErrPermission a 403, os.ErrDeadlineExceeded
+// a 408 and io.ErrUnexpectedEOF a 400. An *HTTPError is returned unchanged, and
+// any other error uses the package default code; see SetDefaultCode.
+func ConvertStdError(err error) *HTTPError {
+	return stdErrorMapper.Map(err)
+}
+
+// pathErrnoCode maps the errno of an *os.PathError to an HTTP status code.
+// errno is an error rather than a syscall.Errno because on plan9 the syscall
+// errors are error values. The table itself is defined per platform.
+type pathErrnoCode struct {
+	errno error
+	code  int
+}
+
+// FromPathError converts an error wrapping an *os.PathError to an HTTPError
+// based on the underlying errno: ENOENT and ENOTDIR become a 404, EACCES, EPERM
+// and EROFS a 403, EEXIST a 409, ENAMETOOLONG a 414, ENOSPC a 507 and ETIMEDOUT
+// a 504. Any other error uses the package default code. A nil err returns nil.
+func FromPathError(err error) *HTTPError {
+	if err == nil {
+		return nil
+	}
+	var pe *os.PathError
+	if errors.As(err, &pe) {
+		for _, m := range pathErrnoCodes {
+			if errors.Is(pe.Err, m.errno) {
+				return WrapError(m.code, err)
+			}
+		}
+	}
+	return WrapError(GetDefaultCode(), err)
+}
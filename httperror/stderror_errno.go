@@ -0,0 +1,21 @@
+//go:build !plan9
+
+package httperror
+
+import (
+	"net/http"
+	"syscall"
+)
+
+// pathErrnoCodes maps the errno of an *os.PathError to an HTTP status code.
+var pathErrnoCodes = []pathErrnoCode{
+	{syscall.ENOENT, http.StatusNotFound},
+	{syscall.ENOTDIR, http.StatusNotFound},
+	{syscall.EACCES, http.StatusForbidden},
+	{syscall.EPERM, http.StatusForbidden},
+	{syscall.EROFS, http.StatusForbidden},
+	{syscall.EEXIST, http.StatusConflict},
+	{syscall.ENAMETOOLONG, http.StatusRequestURITooLong},
+	{syscall.ENOSPC, http.StatusInsufficientStorage},
+	{syscall.ETIMEDOUT, http.StatusGatewayTimeout},
+}
@@ -0,0 +1,18 @@
+package httperror
+
+import (
+	"net/http"
+	"syscall"
+)
+
+// pathErrnoCodes maps the errno of an *os.PathError to an HTTP status code.
+// plan9 has no EROFS or ENOSPC.
+var pathErrnoCodes = []pathErrnoCode{
+	{syscall.ENOENT, http.StatusNotFound},
+	{syscall.ENOTDIR, http.StatusNotFound},
+	{syscall.EACCES, http.StatusForbidden},
+	{syscall.EPERM, http.StatusForbidden},
+	{syscall.EEXIST, http.StatusConflict},
+	{syscall.ENAMETOOLONG, http.StatusRequestURITooLong},
+	{syscall.ETIMEDOUT, http.StatusGatewayTimeout},
+}
@@ -0,0 +1,18 @@
+//go:build !plan9
+
+package httperror
+
+import (
+	"net/http"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromPathErrorUnixErrno(t *testing.T) {
+	t.Run("maps errno without a plan9 equivalent", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, FromPathError(pathErr(syscall.EROFS)).Code)
+		assert.Equal(t, http.StatusInsufficientStorage, FromPathError(pathErr(syscall.ENOSPC)).Code)
+	})
+}
@@ -0,0 +1,86 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertStdError(t *testing.T) {
+	t.Run("maps stdlib errors to status codes", func(t *testing.T) {
+		cases := map[error]int{
+			os.ErrNotExist:         http.StatusNotFound,
+			os.ErrPermission:       http.StatusForbidden,
+			os.ErrDeadlineExceeded: http.StatusRequestTimeout,
+			io.ErrUnexpectedEOF:    http.StatusBadRequest,
+			os.ErrClosed:           http.StatusInternalServerError,
+		}
+		for err, code := range cases {
+			assert.Equal(t, code, ConvertStdError(err).Code, err.Error())
+		}
+	})
+
+	t.Run("maps wrapped path errors", func(t *testing.T) {
+		_, err := os.Open("/does/not/exist")
+		httpErr := ConvertStdError(err)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+		assert.True(t, errors.Is(httpErr, fs.ErrNotExist))
+	})
+
+	t.Run("maps unknown errors to 500", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, ConvertStdError(errors.New("unknown")).Code)
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, ConvertStdError(nil))
+	})
+}
+
+// pathErr returns an *os.PathError for errno.
+func pathErr(errno error) error {
+	return &os.PathError{Op: "open", Path: "/data/file", Err: errno}
+}
+
+func TestFromPathError(t *testing.T) {
+	t.Run("maps errno to status code", func(t *testing.T) {
+		cases := map[error]int{
+			syscall.ENOENT:       http.StatusNotFound,
+			syscall.ENOTDIR:      http.StatusNotFound,
+			syscall.EACCES:       http.StatusForbidden,
+			syscall.EPERM:        http.StatusForbidden,
+			syscall.EEXIST:       http.StatusConflict,
+			syscall.ENAMETOOLONG: http.StatusRequestURITooLong,
+		}
+		for errno, code := range cases {
+			assert.Equal(t, code, FromPathError(pathErr(errno)).Code, errno.Error())
+		}
+	})
+
+	t.Run("finds wrapped path errors", func(t *testing.T) {
+		err := FromPathError(fmt.Errorf("loading config: %w", pathErr(syscall.ENOENT)))
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+
+	t.Run("maps errors from the os package", func(t *testing.T) {
+		_, openErr := os.Open(filepath.Join(t.TempDir(), "missing"))
+		assert.Equal(t, http.StatusNotFound, FromPathError(openErr).Code)
+	})
+
+	t.Run("falls back to 500", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, FromPathError(pathErr(syscall.EINVAL)).Code)
+		assert.Equal(t, http.StatusInternalServerError, FromPathError(errors.New("boom")).Code)
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, FromPathError(nil))
+	})
+}
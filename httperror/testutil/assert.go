@@ -0,0 +1,63 @@
+package testutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Gobusters/ectoerror/httperror"
+)
+
+// AssertHTTPError checks that err's chain contains an HTTPError with the given
+// status code and a message containing msgContains. It reports a failure with
+// the expected and actual values and returns whether the assertion passed.
+func AssertHTTPError(t testing.TB, err error, code int, msgContains string) bool {
+	t.Helper()
+	var httpErr *httperror.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Errorf("expected HTTPError with code %d and message containing %q, got %T: %v", code, msgContains, err, err)
+		return false
+	}
+	if httpErr.Code != code || !strings.Contains(httpErr.Message, msgContains) {
+		t.Errorf("expected HTTPError with code %d and message containing %q, got code %d and message %q",
+			code, msgContains, httpErr.Code, httpErr.Message)
+		return false
+	}
+	return true
+}
+
+// AssertStatus checks that err's chain contains an HTTPError with the expected
+// status code and returns whether the assertion passed.
+func AssertStatus(t testing.TB, err error, expectedCode int) bool {
+	t.Helper()
+	var httpErr *httperror.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Errorf("expected HTTPError with code %d, got %T: %v", expectedCode, err, err)
+		return false
+	}
+	if httpErr.Code != expectedCode {
+		t.Errorf("expected HTTPError with code %d, got code %d and message %q", expectedCode, httpErr.Code, httpErr.Message)
+		return false
+	}
+	return true
+}
+
+// AssertNotHTTPError checks that err's chain does not contain an HTTPError and
+// returns whether the assertion passed.
+func AssertNotHTTPError(t testing.TB, err error) bool {
+	t.Helper()
+	var httpErr *httperror.HTTPError
+	if errors.As(err, &httpErr) {
+		t.Errorf("expected no HTTPError, got code %d and message %q", httpErr.Code, httpErr.Message)
+		return false
+	}
+	return true
+}
+
+// RequireHTTPError is like AssertHTTPError but stops the test with t.FailNow on failure.
+func RequireHTTPError(t testing.TB, err error, code int, msgContains string) {
+	t.Helper()
+	if !AssertHTTPError(t, err, code, msgContains) {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,100 @@
+package testutil
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Gobusters/ectoerror/httperror"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockTB records failures instead of failing the real test.
+type mockTB struct {
+	testing.TB
+	errors  []string
+	stopped bool
+}
+
+func (m *mockTB) Helper() {}
+
+func (m *mockTB) Errorf(format string, args ...any) {
+	m.errors = append(m.errors, fmt.Sprintf(format, args...))
+}
+
+func (m *mockTB) FailNow() {
+	m.stopped = true
+}
+
+func TestAssertHTTPError(t *testing.T) {
+	t.Run("passes for matching error", func(t *testing.T) {
+		m := &mockTB{}
+		err := fmt.Errorf("handler: %w", httperror.NewHTTPError(http.StatusNotFound, "user not found"))
+		assert.True(t, AssertHTTPError(m, err, http.StatusNotFound, "not found"))
+		assert.Empty(t, m.errors)
+	})
+
+	t.Run("reports mismatched code and message", func(t *testing.T) {
+		m := &mockTB{}
+		err := httperror.NewHTTPError(http.StatusGone, "user deleted")
+		assert.False(t, AssertHTTPError(m, err, http.StatusNotFound, "not found"))
+		assert.Equal(t, []string{`expected HTTPError with code 404 and message containing "not found", got code 410 and message "user deleted"`}, m.errors)
+	})
+
+	t.Run("reports non-HTTPError", func(t *testing.T) {
+		m := &mockTB{}
+		assert.False(t, AssertHTTPError(m, errors.New("boom"), http.StatusNotFound, "not found"))
+		assert.Equal(t, []string{`expected HTTPError with code 404 and message containing "not found", got *errors.errorString: boom`}, m.errors)
+	})
+}
+
+func TestAssertStatus(t *testing.T) {
+	t.Run("passes for matching code", func(t *testing.T) {
+		m := &mockTB{}
+		assert.True(t, AssertStatus(m, httperror.NewHTTPError(http.StatusConflict, "conflict"), http.StatusConflict))
+		assert.Empty(t, m.errors)
+	})
+
+	t.Run("reports mismatched code", func(t *testing.T) {
+		m := &mockTB{}
+		assert.False(t, AssertStatus(m, httperror.NewHTTPError(http.StatusConflict, "conflict"), http.StatusNotFound))
+		assert.Equal(t, []string{`expected HTTPError with code 404, got code 409 and message "conflict"`}, m.errors)
+	})
+
+	t.Run("reports nil error", func(t *testing.T) {
+		m := &mockTB{}
+		assert.False(t, AssertStatus(m, nil, http.StatusNotFound))
+		assert.Equal(t, []string{`expected HTTPError with code 404, got <nil>: <nil>`}, m.errors)
+	})
+}
+
+func TestAssertNotHTTPError(t *testing.T) {
+	t.Run("passes for plain and nil errors", func(t *testing.T) {
+		m := &mockTB{}
+		assert.True(t, AssertNotHTTPError(m, errors.New("boom")))
+		assert.True(t, AssertNotHTTPError(m, nil))
+		assert.Empty(t, m.errors)
+	})
+
+	t.Run("reports HTTPError", func(t *testing.T) {
+		m := &mockTB{}
+		assert.False(t, AssertNotHTTPError(m, httperror.NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, []string{`expected no HTTPError, got code 400 and message "bad request"`}, m.errors)
+	})
+}
+
+func TestRequireHTTPError(t *testing.T) {
+	t.Run("does not stop for matching error", func(t *testing.T) {
+		m := &mockTB{}
+		RequireHTTPError(m, httperror.NewHTTPError(http.StatusNotFound, "not found"), http.StatusNotFound, "not found")
+		assert.False(t, m.stopped)
+	})
+
+	t.Run("stops the test on failure", func(t *testing.T) {
+		m := &mockTB{}
+		RequireHTTPError(m, errors.New("boom"), http.StatusNotFound, "")
+		assert.True(t, m.stopped)
+		assert.Len(t, m.errors, 1)
+	})
+}
@@ -0,0 +1,22 @@
+// Package testutil provides helpers for testing code that uses httperror.
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/Gobusters/ectoerror/httperror"
+)
+
+// NewTestServer starts an httptest.Server whose handler returns an *HTTPError.
+// A non-nil error is written with WriteJSONResponse; a nil error results in a 200.
+// The caller must call Close on the returned server.
+func NewTestServer(handler func(w http.ResponseWriter, r *http.Request) *httperror.HTTPError) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if e := handler(w, r); e != nil {
+			httperror.WriteJSONResponse(w, e)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
@@ -0,0 +1,39 @@
+package testutil
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Gobusters/ectoerror/httperror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTestServer(t *testing.T) {
+	t.Run("writes returned HTTPError as JSON", func(t *testing.T) {
+		server := NewTestServer(func(w http.ResponseWriter, r *http.Request) *httperror.HTTPError {
+			return httperror.NewHTTPError(http.StatusNotFound, "not found")
+		})
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"code":404,"message":"not found","meta":{},"schema_version":"1"}`, string(body))
+	})
+
+	t.Run("responds 200 when handler returns nil", func(t *testing.T) {
+		server := NewTestServer(func(w http.ResponseWriter, r *http.Request) *httperror.HTTPError {
+			return nil
+		})
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
@@ -0,0 +1,91 @@
+package httperror
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// textSeparator separates the status code from the message in the text representation.
+const textSeparator = "] HTTP Error: - "
+
+// MarshalText encodes the HTTPError using the same format as Error.
+// Meta and the wrapped error are not encoded.
+func (e *HTTPError) MarshalText() ([]byte, error) {
+	return []byte(e.Error()), nil
+}
+
+// UnmarshalText decodes an HTTPError from the "[%d] HTTP Error: - %s" format produced by MarshalText.
+func (e *HTTPError) UnmarshalText(data []byte) error {
+	text := string(data)
+	end := strings.Index(text, textSeparator)
+	if !strings.HasPrefix(text, "[") || end < 0 {
+		return fmt.Errorf("httperror: invalid text %q: expected format \"[<code>%s<message>\"", text, textSeparator)
+	}
+	code, err := strconv.Atoi(text[1:end])
+	if err != nil {
+		return fmt.Errorf("httperror: invalid status code %q in text %q", text[1:end], text)
+	}
+	e.Code = code
+	e.Message = text[end+len(textSeparator):]
+	e.Meta = make(map[string]any)
+	e.err = nil
+	return nil
+}
+
+// GoString returns Go source that constructs the HTTPError, used by the %#v verb:
+//
+//	httperror.NewHTTPError(404, "not found").AddMetaValue("id", "1")
+//
+// Meta entries are added in key order. A wrapped error cannot be reconstructed,
+// so its type is noted in a trailing comment.
+func (e *HTTPError) GoString() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "httperror.NewHTTPError(%d, %q)", e.Code, e.Message)
+	for _, k := range sortedMetaKeys(e.Meta) {
+		fmt.Fprintf(&b, ".AddMetaValue(%q, %#v)", k, e.Meta[k])
+	}
+	if e.err != nil {
+		fmt.Fprintf(&b, " /* cause: %T */", e.err)
+	}
+	return b.String()
+}
+
+// sortedMetaKeys returns the keys of meta in sorted order.
+func sortedMetaKeys(meta map[string]any) []string {
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Summary returns the message of the HTTPError, for user-facing output.
+func (e *HTTPError) Summary() string {
+	return e.Message
+}
+
+// Detail returns the Error line followed by each Meta entry on its own
+// indented line, in key order:
+//
+//	[404] HTTP Error: - not found
+//	  id: 1
+func (e *HTTPError) Detail() string {
+	var b strings.Builder
+	b.WriteString(e.Error())
+	for _, k := range sortedMetaKeys(e.Meta) {
+		fmt.Fprintf(&b, "\n  %s: %v", k, e.Meta[k])
+	}
+	return b.String()
+}
+
+// Verbose returns Detail followed by the captured stack trace, if any.
+func (e *HTTPError) Verbose() string {
+	stack := e.StackTraceString()
+	if stack == "" {
+		return e.Detail()
+	}
+	return e.Detail() + "\n" + stack
+}
@@ -0,0 +1,115 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorMarshalText(t *testing.T) {
+	t.Run("matches Error output", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request")
+		data, marshalErr := err.MarshalText()
+		assert.NoError(t, marshalErr)
+		assert.Equal(t, err.Error(), string(data))
+	})
+}
+
+func TestHTTPErrorUnmarshalText(t *testing.T) {
+	t.Run("round-trips standard codes", func(t *testing.T) {
+		codes := []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError, http.StatusServiceUnavailable}
+		for _, code := range codes {
+			original := NewHTTPError(code, http.StatusText(code))
+			data, marshalErr := original.MarshalText()
+			assert.NoError(t, marshalErr)
+
+			var decoded HTTPError
+			assert.NoError(t, decoded.UnmarshalText(data))
+			assert.Equal(t, original.Code, decoded.Code)
+			assert.Equal(t, original.Message, decoded.Message)
+			assert.NotNil(t, decoded.Meta)
+		}
+	})
+
+	t.Run("keeps separators inside the message", func(t *testing.T) {
+		var decoded HTTPError
+		assert.NoError(t, decoded.UnmarshalText([]byte("[409] HTTP Error: - conflict: [1] HTTP Error: - nested")))
+		assert.Equal(t, http.StatusConflict, decoded.Code)
+		assert.Equal(t, "conflict: [1] HTTP Error: - nested", decoded.Message)
+	})
+
+	t.Run("returns descriptive error for invalid format", func(t *testing.T) {
+		var decoded HTTPError
+		err := decoded.UnmarshalText([]byte("not an error"))
+		assert.ErrorContains(t, err, `invalid text "not an error"`)
+	})
+
+	t.Run("returns descriptive error for invalid code", func(t *testing.T) {
+		var decoded HTTPError
+		assert.NotPanics(t, func() {
+			err := decoded.UnmarshalText([]byte("[abc] HTTP Error: - bad"))
+			assert.ErrorContains(t, err, `invalid status code "abc"`)
+		})
+	})
+}
+
+func TestHTTPErrorGoString(t *testing.T) {
+	t.Run("formats constructor call", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found")
+		assert.Equal(t, `httperror.NewHTTPError(404, "not found")`, fmt.Sprintf("%#v", err))
+	})
+
+	t.Run("includes sorted meta values", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad \"input\"").
+			AddMetaValue("user", "123").
+			AddMetaValue("attempt", 2)
+		assert.Equal(t,
+			`httperror.NewHTTPError(400, "bad \"input\"").AddMetaValue("attempt", 2).AddMetaValue("user", "123")`,
+			err.GoString())
+	})
+
+	t.Run("notes wrapped cause type", func(t *testing.T) {
+		err := WrapError(http.StatusInternalServerError, errors.New("boom"))
+		assert.Equal(t, `httperror.NewHTTPError(500, "boom") /* cause: *errors.errorString */`, err.GoString())
+	})
+}
+
+func TestHTTPErrorSummary(t *testing.T) {
+	t.Run("returns message", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue("field", "email")
+		assert.Equal(t, "bad request", err.Summary())
+		assert.Equal(t, "", (&HTTPError{}).Summary())
+	})
+}
+
+func TestHTTPErrorDetail(t *testing.T) {
+	t.Run("lists meta in key order", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").
+			AddMetaValue("user_id", 42).
+			AddMetaValue("field", "email")
+		assert.Equal(t, "[400] HTTP Error: - bad request\n  field: email\n  user_id: 42", err.Detail())
+	})
+
+	t.Run("handles zero value", func(t *testing.T) {
+		assert.Equal(t, "[0] HTTP Error: - ", (&HTTPError{}).Detail())
+	})
+}
+
+func TestHTTPErrorVerbose(t *testing.T) {
+	t.Run("matches Detail without stack", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue("field", "email")
+		assert.Equal(t, err.Detail(), err.Verbose())
+		assert.Equal(t, "[0] HTTP Error: - ", (&HTTPError{}).Verbose())
+	})
+
+	t.Run("appends captured stack", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue("field", "email").CaptureStack(0)
+		verbose := err.Verbose()
+		assert.True(t, strings.HasPrefix(verbose, err.Detail()+"\n"))
+		assert.Contains(t, verbose, "TestHTTPErrorVerbose")
+	})
+}
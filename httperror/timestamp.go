@@ -0,0 +1,59 @@
+package httperror
+
+import (
+	"sort"
+	"time"
+)
+
+// TimestampMetaKey is the Meta key used to store the error timestamp.
+const TimestampMetaKey = "timestamp"
+
+// WithTimestamp attaches a timestamp to the HTTPError.
+func (e *HTTPError) WithTimestamp(t time.Time) *HTTPError {
+	return e.AddMetaValue(TimestampMetaKey, t)
+}
+
+// Timestamp returns the timestamp attached to the HTTPError and whether one was set.
+// Timestamps stored as RFC 3339 strings (e.g. after decoding) are parsed.
+func (e *HTTPError) Timestamp() (time.Time, bool) {
+	if e == nil {
+		return time.Time{}, false
+	}
+	switch v := e.Meta[TimestampMetaKey].(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// IsBefore reports whether the HTTPError occurred before other.
+// It returns false if either error lacks a timestamp.
+func (e *HTTPError) IsBefore(other *HTTPError) bool {
+	t1, ok1 := e.Timestamp()
+	t2, ok2 := other.Timestamp()
+	return ok1 && ok2 && t1.Before(t2)
+}
+
+// IsAfter reports whether the HTTPError occurred after other.
+// It returns false if either error lacks a timestamp.
+func (e *HTTPError) IsAfter(other *HTTPError) bool {
+	t1, ok1 := e.Timestamp()
+	t2, ok2 := other.Timestamp()
+	return ok1 && ok2 && t1.After(t2)
+}
+
+// SortByTimestamp sorts errs in chronological order.
+// Errors without a timestamp keep their relative order and are placed last.
+func SortByTimestamp(errs []*HTTPError) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		_, ok1 := errs[i].Timestamp()
+		_, ok2 := errs[j].Timestamp()
+		if ok1 && !ok2 {
+			return true
+		}
+		return errs[i].IsBefore(errs[j])
+	})
+}
@@ -0,0 +1,66 @@
+package httperror
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorWithTimestamp(t *testing.T) {
+	t.Run("stores timestamp in meta", func(t *testing.T) {
+		now := time.Now()
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithTimestamp(now)
+		ts, ok := err.Timestamp()
+		assert.True(t, ok)
+		assert.Equal(t, now, ts)
+	})
+
+	t.Run("parses RFC 3339 string timestamps", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue(TimestampMetaKey, "2024-01-02T03:04:05Z")
+		ts, ok := err.Timestamp()
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ts)
+	})
+
+	t.Run("returns false without timestamp", func(t *testing.T) {
+		_, ok := NewHTTPError(http.StatusBadRequest, "bad request").Timestamp()
+		assert.False(t, ok)
+	})
+}
+
+func TestHTTPErrorIsBeforeIsAfter(t *testing.T) {
+	now := time.Now()
+	earlier := NewHTTPError(http.StatusBadRequest, "earlier").WithTimestamp(now.Add(-time.Minute))
+	later := NewHTTPError(http.StatusBadRequest, "later").WithTimestamp(now)
+	untimed := NewHTTPError(http.StatusBadRequest, "untimed")
+
+	t.Run("compares timestamps", func(t *testing.T) {
+		assert.True(t, earlier.IsBefore(later))
+		assert.False(t, later.IsBefore(earlier))
+		assert.True(t, later.IsAfter(earlier))
+		assert.False(t, earlier.IsAfter(later))
+	})
+
+	t.Run("returns false when a timestamp is missing", func(t *testing.T) {
+		assert.False(t, earlier.IsBefore(untimed))
+		assert.False(t, untimed.IsBefore(earlier))
+		assert.False(t, earlier.IsAfter(untimed))
+		assert.False(t, untimed.IsAfter(earlier))
+	})
+}
+
+func TestSortByTimestamp(t *testing.T) {
+	t.Run("sorts chronologically with untimed errors last", func(t *testing.T) {
+		now := time.Now()
+		first := NewHTTPError(http.StatusBadRequest, "first").WithTimestamp(now.Add(-2 * time.Minute))
+		second := NewHTTPError(http.StatusBadRequest, "second").WithTimestamp(now.Add(-time.Minute))
+		third := NewHTTPError(http.StatusBadRequest, "third").WithTimestamp(now)
+		untimed := NewHTTPError(http.StatusBadRequest, "untimed")
+
+		errs := []*HTTPError{third, untimed, first, second}
+		SortByTimestamp(errs)
+		assert.Equal(t, []*HTTPError{first, second, third, untimed}, errs)
+	})
+}
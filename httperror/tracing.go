@@ -0,0 +1,124 @@
+package httperror
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// TraceParentMetaKey is the Meta key used to store the W3C traceparent header value.
+	TraceParentMetaKey = "traceparent"
+	// TraceStateMetaKey is the Meta key used to store the W3C tracestate header value.
+	TraceStateMetaKey = "tracestate"
+	// RequestIDMetaKey is the Meta key used to store the X-Request-ID of the request.
+	RequestIDMetaKey = "_request_id"
+	// CorrelationIDMetaKey is the Meta key used to store the X-Correlation-ID of the request.
+	CorrelationIDMetaKey = "_correlation_id"
+	// TraceIDMetaKey is the Meta key used to store the trace ID of the request.
+	TraceIDMetaKey = "_trace_id"
+	// SpanIDMetaKey is the Meta key used to store the span ID of the request.
+	SpanIDMetaKey = "_span_id"
+)
+
+// WithTraceParent records the W3C traceparent header value.
+// WriteJSONResponse propagates it as the traceparent response header.
+func (e *HTTPError) WithTraceParent(traceparent string) *HTTPError {
+	return e.AddMetaValue(TraceParentMetaKey, traceparent)
+}
+
+// WithTraceState records the W3C tracestate header value.
+// WriteJSONResponse propagates it as the tracestate response header.
+func (e *HTTPError) WithTraceState(tracestate string) *HTTPError {
+	return e.AddMetaValue(TraceStateMetaKey, tracestate)
+}
+
+// GetTraceParent returns the traceparent recorded on the HTTPError in err's chain.
+func GetTraceParent(err error) string {
+	return metaString(err, TraceParentMetaKey)
+}
+
+// GetTraceState returns the tracestate recorded on the HTTPError in err's chain.
+func GetTraceState(err error) string {
+	return metaString(err, TraceStateMetaKey)
+}
+
+// WithRequestID records the ID of the request that caused the error.
+func (e *HTTPError) WithRequestID(id string) *HTTPError {
+	return e.AddMetaValue(RequestIDMetaKey, id)
+}
+
+// RequestID returns the request ID recorded on the HTTPError in err's chain.
+func RequestID(err error) string {
+	return metaString(err, RequestIDMetaKey)
+}
+
+// WithCorrelationID records the ID correlating the error across services.
+func (e *HTTPError) WithCorrelationID(id string) *HTTPError {
+	return e.AddMetaValue(CorrelationIDMetaKey, id)
+}
+
+// CorrelationID returns the correlation ID recorded on the HTTPError in err's chain.
+func CorrelationID(err error) string {
+	return metaString(err, CorrelationIDMetaKey)
+}
+
+// ExtractFromRequestHeaders returns options for NewWithOptions recording the
+// X-Request-ID and X-Correlation-ID headers of r. Missing headers are skipped.
+func ExtractFromRequestHeaders(r *http.Request) []Option {
+	var opts []Option
+	if id := r.Header.Get("X-Request-ID"); id != "" {
+		opts = append(opts, WithMetaValue(RequestIDMetaKey, id))
+	}
+	if id := r.Header.Get("X-Correlation-ID"); id != "" {
+		opts = append(opts, WithMetaValue(CorrelationIDMetaKey, id))
+	}
+	return opts
+}
+
+// WithTraceID records the trace and span IDs of the request that caused the error.
+func (e *HTTPError) WithTraceID(traceID, spanID string) *HTTPError {
+	return e.AddMetaValue(TraceIDMetaKey, traceID).AddMetaValue(SpanIDMetaKey, spanID)
+}
+
+// TraceID returns the trace and span IDs recorded on the HTTPError in err's chain.
+// ok is false if no trace ID was recorded.
+func TraceID(err error) (traceID, spanID string, ok bool) {
+	traceID = metaString(err, TraceIDMetaKey)
+	if traceID == "" {
+		return "", "", false
+	}
+	return traceID, metaString(err, SpanIDMetaKey), true
+}
+
+// WithTraceIDFromTraceParent records the trace and span IDs of a W3C traceparent
+// value with WithTraceID. Unlike WithTraceParent, the header value itself is not
+// stored. The value usually comes from an untrusted request header, so an invalid
+// traceparent is ignored; use ParseTraceParent to detect one.
+func (e *HTTPError) WithTraceIDFromTraceParent(traceparent string) *HTTPError {
+	traceID, spanID, err := ParseTraceParent(traceparent)
+	if err != nil {
+		return e
+	}
+	return e.WithTraceID(traceID, spanID)
+}
+
+// ParseTraceParent returns the trace and span IDs of a W3C traceparent value of
+// the form "00-{trace-id}-{span-id}-{flags}", or an error if it is malformed.
+func ParseTraceParent(traceparent string) (traceID, spanID string, err error) {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 || !isHex(parts[0], 2) || !isHex(parts[1], 32) || !isHex(parts[2], 16) || !isHex(parts[3], 2) {
+		return "", "", fmt.Errorf("httperror: invalid traceparent %q: expected format \"00-<trace-id>-<span-id>-<flags>\"", traceparent)
+	}
+	return parts[1], parts[2], nil
+}
+
+// isHex reports whether s is a hex string of exactly n characters.
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
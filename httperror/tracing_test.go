@@ -0,0 +1,182 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestHTTPErrorWithTraceParent(t *testing.T) {
+	t.Run("stores traceparent and tracestate in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadGateway, "bad gateway").
+			WithTraceParent(testTraceParent).
+			WithTraceState("congo=t61rcWkgMzE")
+		assert.Equal(t, testTraceParent, err.Meta[TraceParentMetaKey])
+		assert.Equal(t, "congo=t61rcWkgMzE", err.Meta[TraceStateMetaKey])
+	})
+}
+
+func TestGetTraceParent(t *testing.T) {
+	t.Run("returns traceparent and tracestate from wrapped error", func(t *testing.T) {
+		err := fmt.Errorf("handler: %w", NewHTTPError(http.StatusBadGateway, "bad gateway").
+			WithTraceParent(testTraceParent).
+			WithTraceState("congo=t61rcWkgMzE"))
+		assert.Equal(t, testTraceParent, GetTraceParent(err))
+		assert.Equal(t, "congo=t61rcWkgMzE", GetTraceState(err))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", GetTraceParent(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "", GetTraceState(errors.New("standard error")))
+	})
+}
+
+func TestWriteJSONResponseTraceHeaders(t *testing.T) {
+	t.Run("propagates trace headers", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, NewHTTPError(http.StatusBadGateway, "bad gateway").
+			WithTraceParent(testTraceParent).
+			WithTraceState("congo=t61rcWkgMzE"))
+		assert.Equal(t, testTraceParent, rec.Header().Get("traceparent"))
+		assert.Equal(t, "congo=t61rcWkgMzE", rec.Header().Get("tracestate"))
+	})
+
+	t.Run("omits trace headers when not set", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteJSONResponse(rec, NewHTTPError(http.StatusBadGateway, "bad gateway"))
+		assert.Empty(t, rec.Header().Values("traceparent"))
+		assert.Empty(t, rec.Header().Values("tracestate"))
+	})
+}
+
+func TestHTTPErrorWithRequestID(t *testing.T) {
+	t.Run("stores request ID in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithRequestID("req-1")
+		assert.Equal(t, "req-1", err.Meta[RequestIDMetaKey])
+	})
+}
+
+func TestRequestID(t *testing.T) {
+	t.Run("returns request ID from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithRequestID("req-1")
+		assert.Equal(t, "req-1", RequestID(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", RequestID(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "", RequestID(errors.New("standard error")))
+	})
+}
+
+func TestHTTPErrorWithCorrelationID(t *testing.T) {
+	t.Run("stores correlation ID in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithCorrelationID("corr-1")
+		assert.Equal(t, "corr-1", err.Meta[CorrelationIDMetaKey])
+	})
+}
+
+func TestCorrelationID(t *testing.T) {
+	t.Run("returns correlation ID from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithCorrelationID("corr-1")
+		assert.Equal(t, "corr-1", CorrelationID(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", CorrelationID(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "", CorrelationID(errors.New("standard error")))
+	})
+}
+
+func TestExtractFromRequestHeaders(t *testing.T) {
+	t.Run("returns options for both headers", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Request-ID", "req-1")
+		r.Header.Set("X-Correlation-ID", "corr-1")
+		err := NewWithOptions(append(ExtractFromRequestHeaders(r), WithCode(http.StatusNotFound))...)
+		assert.Equal(t, "req-1", RequestID(err))
+		assert.Equal(t, "corr-1", CorrelationID(err))
+	})
+
+	t.Run("skips missing headers", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		assert.Empty(t, ExtractFromRequestHeaders(r))
+	})
+}
+
+func TestHTTPErrorWithTraceID(t *testing.T) {
+	t.Run("stores trace and span IDs in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithTraceID("trace", "span")
+		assert.Equal(t, "trace", err.Meta[TraceIDMetaKey])
+		assert.Equal(t, "span", err.Meta[SpanIDMetaKey])
+	})
+}
+
+func TestTraceID(t *testing.T) {
+	t.Run("returns IDs from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithTraceID("trace", "span")
+		traceID, spanID, ok := TraceID(fmt.Errorf("handler: %w", err))
+		assert.True(t, ok)
+		assert.Equal(t, "trace", traceID)
+		assert.Equal(t, "span", spanID)
+	})
+
+	t.Run("returns false when not set", func(t *testing.T) {
+		_, _, ok := TraceID(NewHTTPError(http.StatusBadRequest, "bad request"))
+		assert.False(t, ok)
+		_, _, ok = TraceID(errors.New("standard error"))
+		assert.False(t, ok)
+	})
+}
+
+func TestHTTPErrorWithTraceIDFromTraceParent(t *testing.T) {
+	t.Run("parses traceparent", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithTraceIDFromTraceParent(testTraceParent)
+		traceID, spanID, ok := TraceID(err)
+		assert.True(t, ok)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+		assert.Equal(t, "00f067aa0ba902b7", spanID)
+		assert.Equal(t, "", GetTraceParent(err))
+	})
+
+	t.Run("ignores invalid traceparent", func(t *testing.T) {
+		for _, traceparent := range invalidTraceParents {
+			var err *HTTPError
+			assert.NotPanics(t, func() {
+				err = NewHTTPError(http.StatusBadRequest, "bad request").WithTraceIDFromTraceParent(traceparent)
+			}, traceparent)
+			_, _, ok := TraceID(err)
+			assert.False(t, ok, traceparent)
+		}
+	})
+}
+
+// invalidTraceParents are malformed W3C traceparent values.
+var invalidTraceParents = []string{
+	"",
+	"not-a-traceparent",
+	"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+	"00-4bf92f3577b34da6-00f067aa0ba902b7-01",
+	"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+}
+
+func TestParseTraceParent(t *testing.T) {
+	t.Run("returns trace and span IDs", func(t *testing.T) {
+		traceID, spanID, err := ParseTraceParent(testTraceParent)
+		assert.NoError(t, err)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+		assert.Equal(t, "00f067aa0ba902b7", spanID)
+	})
+
+	t.Run("returns error for invalid traceparent", func(t *testing.T) {
+		for _, traceparent := range invalidTraceParents {
+			_, _, err := ParseTraceParent(traceparent)
+			assert.Error(t, err, traceparent)
+		}
+	})
+}
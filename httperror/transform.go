@@ -0,0 +1,42 @@
+package httperror
+
+// TransformRule rewrites an HTTPError when its Predicate matches.
+type TransformRule struct {
+	Predicate func(*HTTPError) bool
+	Apply     func(*HTTPError) *HTTPError
+}
+
+// Transform applies the first rule whose Predicate matches e.
+// If no rule matches, e is returned unchanged.
+func Transform(e *HTTPError, rules []TransformRule) *HTTPError {
+	if e == nil {
+		return nil
+	}
+	for _, rule := range rules {
+		if rule.Predicate(e) {
+			return rule.Apply(e)
+		}
+	}
+	return e
+}
+
+// TransformTable is a reusable, ordered set of TransformRules.
+type TransformTable struct {
+	rules []TransformRule
+}
+
+// NewTransformTable creates a new, empty TransformTable.
+func NewTransformTable() *TransformTable {
+	return &TransformTable{}
+}
+
+// Add appends a rule to the table.
+func (t *TransformTable) Add(rule TransformRule) *TransformTable {
+	t.rules = append(t.rules, rule)
+	return t
+}
+
+// Apply transforms e using the first matching rule in the table.
+func (t *TransformTable) Apply(e *HTTPError) *HTTPError {
+	return Transform(e, t.rules)
+}
@@ -0,0 +1,68 @@
+package httperror
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func hideServerErrors() TransformRule {
+	return TransformRule{
+		Predicate: func(e *HTTPError) bool { return e.Code >= http.StatusInternalServerError },
+		Apply: func(e *HTTPError) *HTTPError {
+			return NewHTTPError(http.StatusInternalServerError, "internal error")
+		},
+	}
+}
+
+func recodeNotFound() TransformRule {
+	return TransformRule{
+		Predicate: func(e *HTTPError) bool { return e.Code == http.StatusNotFound },
+		Apply: func(e *HTTPError) *HTTPError {
+			return NewHTTPError(http.StatusGone, e.Message)
+		},
+	}
+}
+
+func TestTransform(t *testing.T) {
+	rules := []TransformRule{hideServerErrors(), recodeNotFound()}
+
+	t.Run("applies first matching rule", func(t *testing.T) {
+		err := Transform(NewHTTPError(http.StatusBadGateway, "upstream exploded"), rules)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		assert.Equal(t, "internal error", err.Message)
+	})
+
+	t.Run("only applies one rule", func(t *testing.T) {
+		always := TransformRule{
+			Predicate: func(e *HTTPError) bool { return true },
+			Apply:     func(e *HTTPError) *HTTPError { return e.AddMetaValue("seen", true) },
+		}
+		err := Transform(NewHTTPError(http.StatusNotFound, "not found"), []TransformRule{recodeNotFound(), always})
+		assert.Equal(t, http.StatusGone, err.Code)
+		assert.NotContains(t, err.Meta, "seen")
+	})
+
+	t.Run("returns error unchanged when no rule matches", func(t *testing.T) {
+		original := NewHTTPError(http.StatusBadRequest, "bad request")
+		assert.Same(t, original, Transform(original, rules))
+	})
+
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, Transform(nil, rules))
+	})
+}
+
+func TestTransformTable(t *testing.T) {
+	t.Run("applies rules in order", func(t *testing.T) {
+		table := NewTransformTable().Add(recodeNotFound()).Add(hideServerErrors())
+		assert.Equal(t, http.StatusGone, table.Apply(NewHTTPError(http.StatusNotFound, "not found")).Code)
+		assert.Equal(t, "internal error", table.Apply(NewHTTPError(http.StatusServiceUnavailable, "down")).Message)
+	})
+
+	t.Run("empty table returns error unchanged", func(t *testing.T) {
+		original := NewHTTPError(http.StatusBadRequest, "bad request")
+		assert.Same(t, original, NewTransformTable().Apply(original))
+	})
+}
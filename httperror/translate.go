@@ -0,0 +1,50 @@
+package httperror
+
+import "sync"
+
+var (
+	translationsMu sync.RWMutex
+	translations   = make(map[int]map[string]string)
+	defaultLang    = "en"
+)
+
+// RegisterTranslation registers message as the translation of errors with code
+// in lang, replacing any translation already registered for that pair.
+func RegisterTranslation(code int, lang string, message string) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	if translations[code] == nil {
+		translations[code] = make(map[string]string)
+	}
+	translations[code][lang] = message
+}
+
+// SetDefaultLang sets the language Translate uses when lang is empty. The default is "en".
+func SetDefaultLang(lang string) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	defaultLang = lang
+}
+
+// Translate returns the translation registered for the status code of err in lang,
+// or the default language if lang is empty. If no translation is registered, the
+// error's message is returned. Errors without an HTTPError in their chain use the
+// package default code and err.Error(). A nil err returns an empty string.
+func Translate(err error, lang string) string {
+	if err == nil {
+		return ""
+	}
+	code, message := GetDefaultCode(), err.Error()
+	if httpErr, ok := AsHTTPError(err); ok {
+		code, message = httpErr.Code, httpErr.Message
+	}
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+	if lang == "" {
+		lang = defaultLang
+	}
+	if translated, ok := translations[code][lang]; ok {
+		return translated
+	}
+	return message
+}
@@ -0,0 +1,85 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// unregisterTranslations removes the translations registered for code.
+func unregisterTranslations(code int) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	delete(translations, code)
+}
+
+func TestTranslate(t *testing.T) {
+	RegisterTranslation(http.StatusNotFound, "de", "Nicht gefunden")
+	RegisterTranslation(http.StatusNotFound, "fr", "Introuvable")
+	defer unregisterTranslations(http.StatusNotFound)
+
+	t.Run("returns registered translation", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "user not found")
+		assert.Equal(t, "Nicht gefunden", Translate(err, "de"))
+		assert.Equal(t, "Introuvable", Translate(fmt.Errorf("handler: %w", err), "fr"))
+	})
+
+	t.Run("falls back to message for unregistered language", func(t *testing.T) {
+		assert.Equal(t, "user not found", Translate(NewHTTPError(http.StatusNotFound, "user not found"), "es"))
+		assert.Equal(t, "conflict", Translate(NewHTTPError(http.StatusConflict, "conflict"), "de"))
+		assert.Equal(t, "standard error", Translate(errors.New("standard error"), "de"))
+	})
+
+	t.Run("uses default language when lang is empty", func(t *testing.T) {
+		defer SetDefaultLang("en")
+		err := NewHTTPError(http.StatusNotFound, "user not found")
+		assert.Equal(t, "user not found", Translate(err, ""))
+		SetDefaultLang("fr")
+		assert.Equal(t, "Introuvable", Translate(err, ""))
+	})
+
+	t.Run("translates plain errors without creating an HTTPError", func(t *testing.T) {
+		RegisterTranslation(http.StatusInternalServerError, "de", "Interner Fehler")
+		defer unregisterTranslations(http.StatusInternalServerError)
+		SetAutoCapture(true)
+		defer SetAutoCapture(false)
+		notified := 0
+		SetAPMHook(func(*HTTPError, map[string]any) { notified++ })
+		defer ClearAPMHook()
+
+		assert.Equal(t, "Interner Fehler", Translate(errors.New("standard error"), "de"))
+		assert.Equal(t, "standard error", Translate(errors.New("standard error"), "fr"))
+		assert.Equal(t, 0, notified)
+	})
+
+	t.Run("nil error returns empty string", func(t *testing.T) {
+		assert.Equal(t, "", Translate(nil, "en"))
+	})
+}
+
+func TestTranslateConcurrent(t *testing.T) {
+	defer unregisterTranslations(http.StatusTeapot)
+	err := NewHTTPError(http.StatusTeapot, "teapot")
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterTranslation(http.StatusTeapot, fmt.Sprintf("lang-%d", i), "translated")
+		}()
+		go func() {
+			defer wg.Done()
+			Translate(err, fmt.Sprintf("lang-%d", i))
+		}()
+	}
+	wg.Wait()
+
+	for i := range 10 {
+		assert.Equal(t, "translated", Translate(err, fmt.Sprintf("lang-%d", i)))
+	}
+}
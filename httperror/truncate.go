@@ -0,0 +1,50 @@
+package httperror
+
+import "sync/atomic"
+
+// truncationSuffix is appended to messages shortened by TruncateMessage.
+const truncationSuffix = "..."
+
+// defaultMaxMessageLength is the message length limit applied to new errors; 0 is unlimited.
+var defaultMaxMessageLength atomic.Int64
+
+// SetDefaultMaxMessageLength sets the maximum message length, in runes, of
+// every new error; longer messages are truncated as by TruncateMessage.
+// A value of 0, the default, means unlimited.
+func SetDefaultMaxMessageLength(n int) {
+	defaultMaxMessageLength.Store(int64(n))
+}
+
+// TruncateMessage returns a clone of e whose Message is truncated to maxLen
+// runes, with "..." appended if it was truncated. A maxLen of 0 or less leaves
+// the message unchanged. A nil e returns nil.
+func TruncateMessage(e *HTTPError, maxLen int) *HTTPError {
+	clone := e.Clone()
+	if clone != nil {
+		clone.Message = truncateMessage(clone.Message, maxLen)
+	}
+	return clone
+}
+
+// truncateMessage truncates message to maxLen runes, appending "..." if it was truncated.
+func truncateMessage(message string, maxLen int) string {
+	if maxLen <= 0 {
+		return message
+	}
+	runes := 0
+	for i := range message {
+		if runes == maxLen {
+			return message[:i] + truncationSuffix
+		}
+		runes++
+	}
+	return message
+}
+
+// applyDefaultMaxMessageLength truncates the message of a new error to the
+// length set by SetDefaultMaxMessageLength.
+func applyDefaultMaxMessageLength(e *HTTPError) {
+	if n := defaultMaxMessageLength.Load(); n > 0 {
+		e.Message = truncateMessage(e.Message, int(n))
+	}
+}
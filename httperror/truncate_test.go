@@ -0,0 +1,50 @@
+package httperror
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateMessage(t *testing.T) {
+	t.Run("truncates long message", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "invalid request body").AddMetaValue("field", "email")
+		truncated := TruncateMessage(err, 7)
+		assert.Equal(t, "invalid...", truncated.Message)
+		assert.Equal(t, "email", truncated.Meta["field"])
+		assert.Equal(t, "invalid request body", err.Message)
+	})
+
+	t.Run("counts runes", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "héllo wörld")
+		assert.Equal(t, "héllo w...", TruncateMessage(err, 7).Message)
+	})
+
+	t.Run("leaves short message unchanged", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request")
+		truncated := TruncateMessage(err, 11)
+		assert.NotSame(t, err, truncated)
+		assert.Equal(t, "bad request", truncated.Message)
+		assert.Equal(t, "bad request", TruncateMessage(err, 0).Message)
+	})
+
+	t.Run("handles nil error", func(t *testing.T) {
+		assert.Nil(t, TruncateMessage(nil, 5))
+	})
+}
+
+func TestSetDefaultMaxMessageLength(t *testing.T) {
+	t.Run("truncates messages of new errors", func(t *testing.T) {
+		SetDefaultMaxMessageLength(4)
+		defer SetDefaultMaxMessageLength(0)
+		assert.Equal(t, "user...", NewHTTPError(http.StatusNotFound, "user not found").Message)
+		assert.Equal(t, "user...", NewHTTPErrorf(http.StatusNotFound, "user %d not found", 1).Message)
+		assert.Equal(t, "user...", NewWithOptions(WithMessage("user not found")).Message)
+		assert.Equal(t, "gone", NewHTTPError(http.StatusGone, "gone").Message)
+	})
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		assert.Equal(t, "user not found", NewHTTPError(http.StatusNotFound, "user not found").Message)
+	})
+}
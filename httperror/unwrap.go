@@ -0,0 +1,71 @@
+package httperror
+
+// AllErrors returns every HTTPError in err's chain, outermost first. The chain
+// is walked depth-first, following both Unwrap() error and Unwrap() []error.
+func AllErrors(err error) []*HTTPError {
+	var errs []*HTTPError
+	walkChain(err, func(e error) {
+		if httpErr, ok := e.(*HTTPError); ok {
+			errs = append(errs, httpErr)
+		}
+	})
+	return errs
+}
+
+// walkChain calls fn for err and every error in its chain, depth-first.
+func walkChain(err error, fn func(error)) {
+	if err == nil {
+		return
+	}
+	fn(err)
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		walkChain(u.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			walkChain(e, fn)
+		}
+	}
+}
+
+// FirstHTTPError returns the outermost HTTPError in err's chain, or nil if there is none.
+func FirstHTTPError(err error) *HTTPError {
+	errs := AllErrors(err)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[0]
+}
+
+// LastHTTPError returns the innermost HTTPError in err's chain, or nil if there is none.
+func LastHTTPError(err error) *HTTPError {
+	errs := AllErrors(err)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[len(errs)-1]
+}
+
+// RootCause returns the deepest error in err's chain that does not wrap another
+// error. For joined errors, the first non-nil error is followed.
+func RootCause(err error) error {
+	for err != nil {
+		var next error
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			next = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if e != nil {
+					next = e
+					break
+				}
+			}
+		}
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllErrors(t *testing.T) {
+	t.Run("finds singly wrapped error", func(t *testing.T) {
+		inner := NewHTTPError(http.StatusNotFound, "not found")
+		assert.Equal(t, []*HTTPError{inner}, AllErrors(fmt.Errorf("handler: %w", inner)))
+	})
+
+	t.Run("finds doubly wrapped errors outermost first", func(t *testing.T) {
+		inner := NewHTTPError(http.StatusNotFound, "not found")
+		outer := WrapIfChanged(fmt.Errorf("repo: %w", inner), http.StatusBadGateway, "upstream failed")
+		assert.Equal(t, []*HTTPError{outer, inner}, AllErrors(fmt.Errorf("handler: %w", outer)))
+	})
+
+	t.Run("finds errors joined with errors.Join", func(t *testing.T) {
+		a := NewHTTPError(http.StatusBadRequest, "a")
+		b := NewHTTPError(http.StatusConflict, "b")
+		assert.Equal(t, []*HTTPError{a, b}, AllErrors(errors.Join(a, errors.New("plain"), fmt.Errorf("wrapped: %w", b))))
+	})
+
+	t.Run("returns empty slice without HTTPErrors", func(t *testing.T) {
+		assert.Empty(t, AllErrors(fmt.Errorf("wrapped: %w", errors.New("plain"))))
+	})
+
+	t.Run("returns empty slice for nil", func(t *testing.T) {
+		assert.Empty(t, AllErrors(nil))
+	})
+}
+
+func TestFirstHTTPError(t *testing.T) {
+	t.Run("returns outermost error", func(t *testing.T) {
+		inner := NewHTTPError(http.StatusNotFound, "not found")
+		outer := WrapIfChanged(inner, http.StatusBadGateway, "upstream failed")
+		assert.Same(t, outer, FirstHTTPError(fmt.Errorf("handler: %w", outer)))
+	})
+
+	t.Run("returns nil without HTTPErrors", func(t *testing.T) {
+		assert.Nil(t, FirstHTTPError(errors.New("plain")))
+		assert.Nil(t, FirstHTTPError(nil))
+	})
+}
+
+func TestLastHTTPError(t *testing.T) {
+	t.Run("returns innermost error", func(t *testing.T) {
+		inner := NewHTTPError(http.StatusNotFound, "not found")
+		outer := WrapIfChanged(inner, http.StatusBadGateway, "upstream failed")
+		assert.Same(t, inner, LastHTTPError(fmt.Errorf("handler: %w", outer)))
+	})
+
+	t.Run("returns nil without HTTPErrors", func(t *testing.T) {
+		assert.Nil(t, LastHTTPError(errors.New("plain")))
+		assert.Nil(t, LastHTTPError(nil))
+	})
+}
+
+func TestRootCause(t *testing.T) {
+	t.Run("returns deepest error", func(t *testing.T) {
+		root := errors.New("connection refused")
+		err := fmt.Errorf("handler: %w", WrapError(http.StatusBadGateway, root))
+		assert.Equal(t, root, RootCause(err))
+	})
+
+	t.Run("follows first joined error", func(t *testing.T) {
+		root := errors.New("first")
+		assert.Equal(t, root, RootCause(errors.Join(fmt.Errorf("wrapped: %w", root), errors.New("second"))))
+	})
+
+	t.Run("returns error that wraps nothing", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found")
+		assert.Equal(t, err, RootCause(err))
+	})
+
+	t.Run("returns nil for nil", func(t *testing.T) {
+		assert.Nil(t, RootCause(nil))
+	})
+}
@@ -0,0 +1,43 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the structural invariants of the HTTPError: the status code
+// must be in the range [100, 599], the message must be non-empty and every
+// Meta key must be non-empty. It returns an error listing all violations, or
+// nil if the HTTPError is valid.
+func (e *HTTPError) Validate() error {
+	var violations []error
+	if e.Code < 100 || e.Code > 599 {
+		violations = append(violations, fmt.Errorf("status code %d is out of range [100, 599]", e.Code))
+	}
+	if e.Message == "" {
+		violations = append(violations, errors.New("message is empty"))
+	}
+	if _, ok := e.Meta[""]; ok {
+		violations = append(violations, errors.New("meta contains an empty key"))
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid HTTPError: %w", errors.Join(violations...))
+}
+
+// ValidateAll validates each HTTPError in errs and returns the errors for the
+// invalid ones, prefixed with their index. Nil entries are reported as invalid.
+func ValidateAll(errs []*HTTPError) []error {
+	var invalid []error
+	for i, e := range errs {
+		if e == nil {
+			invalid = append(invalid, fmt.Errorf("errs[%d]: nil HTTPError", i))
+			continue
+		}
+		if err := e.Validate(); err != nil {
+			invalid = append(invalid, fmt.Errorf("errs[%d]: %w", i, err))
+		}
+	}
+	return invalid
+}
@@ -0,0 +1,60 @@
+package httperror
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorValidate(t *testing.T) {
+	t.Run("returns nil for valid error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("id", "1")
+		assert.NoError(t, err.Validate())
+	})
+
+	t.Run("reports out of range code", func(t *testing.T) {
+		err := NewHTTPError(600, "bad code")
+		assert.ErrorContains(t, err.Validate(), "status code 600 is out of range")
+	})
+
+	t.Run("reports empty message", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "")
+		assert.ErrorContains(t, err.Validate(), "message is empty")
+	})
+
+	t.Run("reports empty meta key", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("", "value")
+		assert.ErrorContains(t, err.Validate(), "meta contains an empty key")
+	})
+
+	t.Run("lists all violations", func(t *testing.T) {
+		err := NewHTTPError(99, "").AddMetaValue("", "value")
+		validateErr := err.Validate()
+		assert.ErrorContains(t, validateErr, "status code 99")
+		assert.ErrorContains(t, validateErr, "message is empty")
+		assert.ErrorContains(t, validateErr, "empty key")
+	})
+}
+
+func TestValidateAll(t *testing.T) {
+	t.Run("returns nil when all are valid", func(t *testing.T) {
+		errs := []*HTTPError{
+			NewHTTPError(http.StatusBadRequest, "bad request"),
+			NewHTTPError(http.StatusNotFound, "not found"),
+		}
+		assert.Empty(t, ValidateAll(errs))
+	})
+
+	t.Run("returns errors for invalid entries", func(t *testing.T) {
+		errs := []*HTTPError{
+			NewHTTPError(http.StatusBadRequest, "bad request"),
+			NewHTTPError(0, "no code"),
+			nil,
+		}
+		invalid := ValidateAll(errs)
+		assert.Len(t, invalid, 2)
+		assert.ErrorContains(t, invalid[0], "errs[1]")
+		assert.ErrorContains(t, invalid[1], "errs[2]: nil HTTPError")
+	})
+}
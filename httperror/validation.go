@@ -0,0 +1,145 @@
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ValidationErrorsMetaKey is the Meta key used to store field validation errors.
+const ValidationErrorsMetaKey = "validation_errors"
+
+// NewBatchValidationError creates a 422 HTTPError holding a field-to-message map of validation errors.
+func NewBatchValidationError(fieldErrors map[string]string) *HTTPError {
+	return newHTTPError(http.StatusUnprocessableEntity, "validation failed", nil,
+		WithMetaValue(ValidationErrorsMetaKey, fieldErrors))
+}
+
+// GetValidationErrors returns the field validation errors stored on the HTTPError in err's chain.
+func GetValidationErrors(err error) map[string]string {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return nil
+	}
+	switch v := httpErr.Meta[ValidationErrorsMetaKey].(type) {
+	case map[string]string:
+		return v
+	case map[string]any:
+		fieldErrors := make(map[string]string, len(v))
+		for field, msg := range v {
+			if s, ok := msg.(string); ok {
+				fieldErrors[field] = s
+			}
+		}
+		return fieldErrors
+	}
+	return nil
+}
+
+// FieldError describes a single failed validation rule for a request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Param   string `json:"param"`
+	Message string `json:"message"`
+}
+
+// ValidationError is a 422 HTTPError collecting the field errors of a request.
+type ValidationError struct {
+	*HTTPError
+	Fields []FieldError
+}
+
+// NewValidationError creates a ValidationError holding the given field errors.
+func NewValidationError(fields ...FieldError) *ValidationError {
+	return &ValidationError{
+		HTTPError: newHTTPError(http.StatusUnprocessableEntity, "validation failed", nil),
+		Fields:    fields,
+	}
+}
+
+// AddField appends f to the field errors of the ValidationError.
+func (v *ValidationError) AddField(f FieldError) *ValidationError {
+	v.Fields = append(v.Fields, f)
+	return v
+}
+
+// HasField reports whether the ValidationError has an error for field.
+func (v *ValidationError) HasField(field string) bool {
+	for _, f := range v.Fields {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldsForField returns the field errors for field, in the order they were added.
+func (v *ValidationError) FieldsForField(field string) []FieldError {
+	var fields []FieldError
+	for _, f := range v.Fields {
+		if f.Field == field {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Error lists the field errors after the HTTPError text, e.g.
+// "[422] HTTP Error: - validation failed: email: invalid email; name: required".
+func (v *ValidationError) Error() string {
+	if len(v.Fields) == 0 {
+		return v.HTTPError.Error()
+	}
+	parts := make([]string, len(v.Fields))
+	for i, f := range v.Fields {
+		parts[i] = f.Field + ": " + f.Message
+	}
+	return v.HTTPError.Error() + ": " + strings.Join(parts, "; ")
+}
+
+// Unwrap returns the embedded HTTPError so errors.As can extract it.
+func (v *ValidationError) Unwrap() error {
+	return v.HTTPError
+}
+
+// MarshalJSON encodes the ValidationError as its HTTPError with an added "fields" array.
+func (v *ValidationError) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(v.HTTPError)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		obj = make(map[string]json.RawMessage)
+	}
+	fields := v.Fields
+	if fields == nil {
+		fields = []FieldError{}
+	}
+	if obj["fields"], err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON decodes the ValidationError from its JSON representation.
+func (v *ValidationError) UnmarshalJSON(data []byte) error {
+	e := &HTTPError{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return err
+	}
+	var obj struct {
+		Fields []FieldError `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	v.HTTPError = e
+	v.Fields = obj.Fields
+	return nil
+}
@@ -0,0 +1,145 @@
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBatchValidationError(t *testing.T) {
+	t.Run("creates 422 with validation errors", func(t *testing.T) {
+		fieldErrors := map[string]string{"email": "invalid email", "age": "must be positive"}
+		err := NewBatchValidationError(fieldErrors)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.Code)
+		assert.Equal(t, "validation failed", err.Message)
+		assert.Equal(t, fieldErrors, err.Meta[ValidationErrorsMetaKey])
+	})
+
+	t.Run("includes validation errors as top-level JSON field", func(t *testing.T) {
+		err := NewBatchValidationError(map[string]string{"email": "invalid email"}).AddMetaValue("user_id", "123")
+		data, marshalErr := json.Marshal(err)
+		assert.NoError(t, marshalErr)
+		assert.JSONEq(t, `{"code":422,"message":"validation failed","meta":{"user_id":"123"},"validation_errors":{"email":"invalid email"},"schema_version":"1"}`, string(data))
+	})
+
+	t.Run("round-trips validation errors through JSON", func(t *testing.T) {
+		fieldErrors := map[string]string{"email": "invalid email"}
+		data, marshalErr := json.Marshal(NewBatchValidationError(fieldErrors))
+		assert.NoError(t, marshalErr)
+
+		decoded, decodeErr := NewHTTPErrorFromJSON(data)
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, fieldErrors, GetValidationErrors(decoded))
+	})
+}
+
+func TestGetValidationErrors(t *testing.T) {
+	t.Run("returns validation errors from wrapped error", func(t *testing.T) {
+		fieldErrors := map[string]string{"email": "invalid email"}
+		err := fmt.Errorf("handler: %w", NewBatchValidationError(fieldErrors))
+		assert.Equal(t, fieldErrors, GetValidationErrors(err))
+	})
+
+	t.Run("converts generic maps", func(t *testing.T) {
+		err := NewHTTPError(http.StatusUnprocessableEntity, "validation failed").
+			AddMetaValue(ValidationErrorsMetaKey, map[string]any{"email": "invalid email"})
+		assert.Equal(t, map[string]string{"email": "invalid email"}, GetValidationErrors(err))
+	})
+
+	t.Run("returns nil when not set", func(t *testing.T) {
+		assert.Nil(t, GetValidationErrors(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Nil(t, GetValidationErrors(errors.New("standard error")))
+	})
+}
+
+func TestNewValidationError(t *testing.T) {
+	t.Run("creates 422 with field errors", func(t *testing.T) {
+		email := FieldError{Field: "email", Tag: "email", Message: "invalid email"}
+		age := FieldError{Field: "age", Tag: "gte", Param: "18", Message: "must be at least 18"}
+		err := NewValidationError(email, age)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.Code)
+		assert.Equal(t, "validation failed", err.Message)
+		assert.Equal(t, []FieldError{email, age}, err.Fields)
+		assert.Equal(t, "[422] HTTP Error: - validation failed: email: invalid email; age: must be at least 18", err.Error())
+	})
+
+	t.Run("error without fields", func(t *testing.T) {
+		assert.Equal(t, "[422] HTTP Error: - validation failed", NewValidationError().Error())
+	})
+
+	t.Run("errors.As extracts HTTPError", func(t *testing.T) {
+		var err error = NewValidationError(FieldError{Field: "email", Message: "invalid email"})
+		var httpErr *HTTPError
+		assert.True(t, errors.As(fmt.Errorf("handler: %w", err), &httpErr))
+		assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+
+		var validationErr *ValidationError
+		assert.True(t, errors.As(fmt.Errorf("handler: %w", err), &validationErr))
+		assert.True(t, validationErr.HasField("email"))
+	})
+}
+
+func TestValidationErrorFields(t *testing.T) {
+	t.Run("collects duplicate field names", func(t *testing.T) {
+		required := FieldError{Field: "password", Tag: "required", Message: "is required"}
+		minLen := FieldError{Field: "password", Tag: "min", Param: "8", Message: "too short"}
+		err := NewValidationError().
+			AddField(required).
+			AddField(FieldError{Field: "email", Tag: "email", Message: "invalid email"}).
+			AddField(minLen)
+		assert.Len(t, err.Fields, 3)
+		assert.True(t, err.HasField("password"))
+		assert.True(t, err.HasField("email"))
+		assert.False(t, err.HasField("name"))
+		assert.Equal(t, []FieldError{required, minLen}, err.FieldsForField("password"))
+		assert.Nil(t, err.FieldsForField("name"))
+	})
+}
+
+func TestValidationErrorJSON(t *testing.T) {
+	t.Run("includes fields array", func(t *testing.T) {
+		err := NewValidationError(FieldError{Field: "email", Tag: "email", Message: "invalid email"})
+		data, marshalErr := json.Marshal(err)
+		assert.NoError(t, marshalErr)
+
+		var obj map[string]any
+		assert.NoError(t, json.Unmarshal(data, &obj))
+		assert.Equal(t, float64(http.StatusUnprocessableEntity), obj["code"])
+		assert.Equal(t, "validation failed", obj["message"])
+		assert.Equal(t, map[string]any{}, obj["meta"])
+		assert.Equal(t, []any{map[string]any{"field": "email", "tag": "email", "param": "", "message": "invalid email"}}, obj["fields"])
+	})
+
+	t.Run("round-trips through JSON", func(t *testing.T) {
+		original := NewValidationError(
+			FieldError{Field: "email", Tag: "email", Message: "invalid email"},
+			FieldError{Field: "age", Tag: "gte", Param: "18", Message: "must be at least 18"},
+		)
+		original.AddMetaValue("user_id", "123")
+		data, marshalErr := json.Marshal(original)
+		assert.NoError(t, marshalErr)
+
+		var decoded ValidationError
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, original.Code, decoded.Code)
+		assert.Equal(t, original.Message, decoded.Message)
+		assert.Equal(t, "123", decoded.Meta["user_id"])
+		assert.Equal(t, original.Fields, decoded.Fields)
+	})
+
+	t.Run("encodes empty fields as array", func(t *testing.T) {
+		data, marshalErr := json.Marshal(NewValidationError())
+		assert.NoError(t, marshalErr)
+		assert.Contains(t, string(data), `"fields":[]`)
+	})
+
+	t.Run("handles missing HTTPError", func(t *testing.T) {
+		data, marshalErr := json.Marshal(&ValidationError{})
+		assert.NoError(t, marshalErr)
+		assert.JSONEq(t, `{"fields":[]}`, string(data))
+	})
+}
@@ -0,0 +1,22 @@
+package httperror
+
+import "net/http"
+
+// HTTPVersionMetaKey is the Meta key used to store the HTTP protocol version of the request.
+const HTTPVersionMetaKey = "http_version"
+
+// WithHTTPVersion records the HTTP protocol version of the request, such as "HTTP/1.0".
+func (e *HTTPError) WithHTTPVersion(version string) *HTTPError {
+	return e.AddMetaValue(HTTPVersionMetaKey, version)
+}
+
+// GetHTTPVersion returns the HTTP protocol version recorded on the HTTPError in err's chain.
+func GetHTTPVersion(err error) string {
+	return metaString(err, HTTPVersionMetaKey)
+}
+
+// NewHTTPVersionNotSupported creates a 505 HTTPError recording the unsupported protocol version.
+func NewHTTPVersionNotSupported(version string) *HTTPError {
+	return newHTTPError(http.StatusHTTPVersionNotSupported, http.StatusText(http.StatusHTTPVersionNotSupported), nil,
+		WithMetaValue(HTTPVersionMetaKey, version))
+}
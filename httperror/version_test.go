@@ -0,0 +1,39 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorWithHTTPVersion(t *testing.T) {
+	t.Run("stores HTTP version in meta", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithHTTPVersion("HTTP/1.0")
+		assert.Equal(t, "HTTP/1.0", err.Meta[HTTPVersionMetaKey])
+	})
+}
+
+func TestGetHTTPVersion(t *testing.T) {
+	t.Run("returns HTTP version from wrapped error", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").WithHTTPVersion("HTTP/2.0")
+		assert.Equal(t, "HTTP/2.0", GetHTTPVersion(fmt.Errorf("handler: %w", err)))
+	})
+
+	t.Run("returns empty string when not set", func(t *testing.T) {
+		assert.Equal(t, "", GetHTTPVersion(NewHTTPError(http.StatusBadRequest, "bad request")))
+		assert.Equal(t, "", GetHTTPVersion(errors.New("standard error")))
+	})
+}
+
+func TestNewHTTPVersionNotSupported(t *testing.T) {
+	t.Run("creates 505 with HTTP version", func(t *testing.T) {
+		err := NewHTTPVersionNotSupported("HTTP/0.9")
+		assert.Equal(t, http.StatusHTTPVersionNotSupported, err.Code)
+		assert.Equal(t, "HTTP Version Not Supported", err.Message)
+		assert.Equal(t, "HTTP/0.9", GetHTTPVersion(err))
+		assert.True(t, IsHTTPVersionNotSupported(err))
+	})
+}
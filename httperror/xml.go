@@ -0,0 +1,78 @@
+package httperror
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+// xmlHTTPError is the XML representation of an HTTPError.
+type xmlHTTPError struct {
+	Code    int     `xml:"code"`
+	Message string  `xml:"message"`
+	Meta    xmlMeta `xml:"meta"`
+}
+
+// xmlMeta holds Meta entries as <entry key="k">v</entry> elements.
+type xmlMeta struct {
+	Entries []xmlMetaEntry `xml:"entry"`
+}
+
+type xmlMetaEntry struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// NewHTTPErrorFromXML creates a new HTTPError from its XML representation.
+func NewHTTPErrorFromXML(data []byte) (*HTTPError, error) {
+	e := &HTTPError{}
+	if err := xml.Unmarshal(data, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// MarshalXML encodes the HTTPError as <error><code/><message/><meta/></error>.
+// Meta values are formatted as strings and entries are sorted by key.
+func (e *HTTPError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	meta := encodableMeta(e.Meta)
+	v := xmlHTTPError{Code: e.Code, Message: e.Message}
+	for _, k := range sortedMetaKeys(meta) {
+		v.Meta.Entries = append(v.Meta.Entries, xmlMetaEntry{Key: k, Value: fmt.Sprint(meta[k])})
+	}
+	start.Name = xml.Name{Local: "error"}
+	return enc.EncodeElement(v, start)
+}
+
+// UnmarshalXML decodes the HTTPError from its XML representation.
+// Meta values are decoded as strings and the HTTPError does not wrap an error.
+func (e *HTTPError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var v xmlHTTPError
+	if err := dec.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	e.Code = v.Code
+	e.Message = v.Message
+	e.Meta = make(map[string]any, len(v.Meta.Entries))
+	for _, entry := range v.Meta.Entries {
+		e.Meta[entry.Key] = entry.Value
+	}
+	e.err = nil
+	return nil
+}
+
+// WriteXML writes err to w as an XML response using the error's status code.
+// Errors that are not HTTPErrors are written with the default code.
+// Sensitive Meta values are redacted; see RegisterSensitiveKey.
+func WriteXML(w http.ResponseWriter, err error) {
+	httpErr := ToHTTPError(err)
+	if httpErr == nil {
+		return
+	}
+	data, marshalErr := xml.Marshal(httpErr.Redacted())
+	if marshalErr != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	_ = writeBody(w, httpErr, httpErr.Code, contentTypeXML, append([]byte(xml.Header), data...))
+}
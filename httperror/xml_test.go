@@ -0,0 +1,83 @@
+package httperror
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorMarshalXML(t *testing.T) {
+	t.Run("encodes fields and meta entries", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("k", "v")
+		data, marshalErr := xml.Marshal(err)
+		assert.NoError(t, marshalErr)
+		assert.Equal(t, `<error><code>404</code><message>not found</message><meta><entry key="k">v</entry></meta></error>`, string(data))
+	})
+
+	t.Run("encodes empty meta", func(t *testing.T) {
+		data, marshalErr := xml.Marshal(NewHTTPError(http.StatusBadRequest, "bad request"))
+		assert.NoError(t, marshalErr)
+		assert.Equal(t, `<error><code>400</code><message>bad request</message><meta></meta></error>`, string(data))
+	})
+
+	t.Run("coerces integer meta values to strings", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").AddMetaValue("attempt", 3)
+		data, marshalErr := xml.Marshal(err)
+		assert.NoError(t, marshalErr)
+		assert.Contains(t, string(data), `<entry key="attempt">3</entry>`)
+
+		decoded, decodeErr := NewHTTPErrorFromXML(data)
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, "3", decoded.Meta["attempt"])
+	})
+}
+
+func TestHTTPErrorUnmarshalXML(t *testing.T) {
+	t.Run("round-trips fields and meta", func(t *testing.T) {
+		original := NewHTTPError(http.StatusConflict, "conflict").
+			AddMetaValue("a", "1").
+			AddMetaValue("b", "two")
+		data, marshalErr := xml.Marshal(original)
+		assert.NoError(t, marshalErr)
+
+		decoded, decodeErr := NewHTTPErrorFromXML(data)
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, original.Code, decoded.Code)
+		assert.Equal(t, original.Message, decoded.Message)
+		assert.Equal(t, original.Meta, decoded.Meta)
+		assert.Nil(t, decoded.Unwrap())
+	})
+
+	t.Run("decodes empty meta as empty map", func(t *testing.T) {
+		decoded, decodeErr := NewHTTPErrorFromXML([]byte(`<error><code>400</code><message>bad</message></error>`))
+		assert.NoError(t, decodeErr)
+		assert.NotNil(t, decoded.Meta)
+		assert.Empty(t, decoded.Meta)
+	})
+
+	t.Run("returns error for invalid XML", func(t *testing.T) {
+		_, decodeErr := NewHTTPErrorFromXML([]byte(`<error><code>`))
+		assert.Error(t, decodeErr)
+	})
+}
+
+func TestWriteXML(t *testing.T) {
+	t.Run("writes HTTPError as XML", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteXML(rec, NewHTTPError(http.StatusNotFound, "not found"))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "application/xml; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, xml.Header+`<error><code>404</code><message>not found</message><meta></meta></error>`, rec.Body.String())
+	})
+
+	t.Run("writes plain error as 500", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteXML(rec, errors.New("boom"))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "<message>boom</message>")
+	})
+}
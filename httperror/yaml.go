@@ -0,0 +1,93 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlHTTPError is the YAML representation of an HTTPError.
+type yamlHTTPError struct {
+	StatusCode int            `yaml:"status_code"`
+	Message    string         `yaml:"message"`
+	Meta       map[string]any `yaml:"meta"`
+}
+
+// NewHTTPErrorFromYAML creates a new HTTPError from its YAML representation.
+func NewHTTPErrorFromYAML(data []byte) (*HTTPError, error) {
+	e := &HTTPError{}
+	if err := yaml.Unmarshal(data, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// LoadFromYAML decodes a YAML document mapping error names to HTTPErrors, each
+// using the keys status_code, message and meta, and registers every error in
+// the global error registry under its name:
+//
+//	user_not_found:
+//	  status_code: 404
+//	  message: user not found
+//
+// No errors are registered if the document is invalid.
+func LoadFromYAML(r io.Reader) (map[string]*HTTPError, error) {
+	errs := make(map[string]*HTTPError)
+	if err := yaml.NewDecoder(r).Decode(&errs); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for name, e := range errs {
+		if e == nil {
+			return nil, fmt.Errorf("httperror: error %q has no definition", name)
+		}
+	}
+	for name, e := range errs {
+		RegisterError(name, e)
+	}
+	return errs, nil
+}
+
+// MarshalYAML encodes the HTTPError using the keys status_code, message and meta.
+// Meta values that YAML cannot represent are formatted as strings.
+func (e *HTTPError) MarshalYAML() (any, error) {
+	meta := encodableMeta(e.Meta)
+	for k, v := range meta {
+		meta[k] = yamlValue(v)
+	}
+	return yamlHTTPError{StatusCode: e.Code, Message: e.Message, Meta: meta}, nil
+}
+
+// UnmarshalYAML decodes the HTTPError from its YAML representation.
+// The decoded HTTPError does not wrap an error.
+func (e *HTTPError) UnmarshalYAML(value *yaml.Node) error {
+	var v yamlHTTPError
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	if v.Meta == nil {
+		v.Meta = make(map[string]any)
+	}
+	e.Code = v.StatusCode
+	e.Message = v.Message
+	e.Meta = v.Meta
+	e.err = nil
+	return nil
+}
+
+// yamlValue returns v if YAML can represent it, otherwise its string form.
+func yamlValue(v any) any {
+	switch value := v.(type) {
+	case nil, yaml.Marshaler:
+		return v
+	case error:
+		return value.Error()
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Func, reflect.Chan, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return fmt.Sprint(v)
+	}
+	return v
+}
@@ -0,0 +1,121 @@
+package httperror
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestHTTPErrorMarshalYAML(t *testing.T) {
+	t.Run("uses snake_case keys", func(t *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "not found").AddMetaValue("user_id", "123")
+		data, marshalErr := yaml.Marshal(err)
+		assert.NoError(t, marshalErr)
+		assert.Equal(t, "status_code: 404\nmessage: not found\nmeta:\n    user_id: \"123\"\n", string(data))
+	})
+
+	t.Run("falls back to strings for unsupported meta values", func(t *testing.T) {
+		err := NewHTTPError(http.StatusBadRequest, "bad request").
+			AddMetaValue("cause", errors.New("boom")).
+			AddMetaValue("z", complex(1, 2))
+		data, marshalErr := yaml.Marshal(err)
+		assert.NoError(t, marshalErr)
+
+		decoded, decodeErr := NewHTTPErrorFromYAML(data)
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, "boom", decoded.Meta["cause"])
+		assert.Equal(t, "(1+2i)", decoded.Meta["z"])
+	})
+}
+
+func TestHTTPErrorUnmarshalYAML(t *testing.T) {
+	t.Run("round-trips through a buffer", func(t *testing.T) {
+		original := NewHTTPError(http.StatusConflict, "conflict").
+			AddMetaValue("user_id", "123").
+			AddMetaValue("attempt", 2).
+			AddMetaValue("retryable", true)
+
+		var buf bytes.Buffer
+		assert.NoError(t, yaml.NewEncoder(&buf).Encode(original))
+
+		var decoded HTTPError
+		assert.NoError(t, yaml.NewDecoder(&buf).Decode(&decoded))
+		assert.Equal(t, original.Code, decoded.Code)
+		assert.Equal(t, original.Message, decoded.Message)
+		assert.Equal(t, original.Meta, decoded.Meta)
+		assert.Nil(t, decoded.Unwrap())
+	})
+
+	t.Run("preserves nested meta values", func(t *testing.T) {
+		original := NewHTTPError(http.StatusBadRequest, "bad request").
+			AddMetaValue("fields", map[string]any{"email": "invalid", "age": 3}).
+			AddMetaValue("tags", []any{"a", 1, 2.5})
+		data, marshalErr := yaml.Marshal(original)
+		assert.NoError(t, marshalErr)
+
+		decoded, decodeErr := NewHTTPErrorFromYAML(data)
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, map[string]any{"email": "invalid", "age": 3}, decoded.Meta["fields"])
+		assert.Equal(t, []any{"a", 1, 2.5}, decoded.Meta["tags"])
+	})
+
+	t.Run("decodes missing meta as empty map", func(t *testing.T) {
+		decoded, decodeErr := NewHTTPErrorFromYAML([]byte("status_code: 400\nmessage: bad\n"))
+		assert.NoError(t, decodeErr)
+		assert.NotNil(t, decoded.Meta)
+	})
+
+	t.Run("returns error for invalid YAML", func(t *testing.T) {
+		_, decodeErr := NewHTTPErrorFromYAML([]byte("status_code: [nope"))
+		assert.Error(t, decodeErr)
+	})
+}
+
+func TestLoadFromYAML(t *testing.T) {
+	t.Run("loads and registers errors by name", func(t *testing.T) {
+		doc := `
+yaml_user_not_found:
+  status_code: 404
+  message: user not found
+  meta:
+    resource: user
+yaml_rate_limited:
+  status_code: 429
+  message: slow down
+`
+		errs, err := LoadFromYAML(strings.NewReader(doc))
+		assert.NoError(t, err)
+		assert.Len(t, errs, 2)
+		assert.Equal(t, http.StatusNotFound, errs["yaml_user_not_found"].Code)
+		assert.Equal(t, "user not found", errs["yaml_user_not_found"].Message)
+		assert.Equal(t, "user", errs["yaml_user_not_found"].Meta["resource"])
+		assert.NotNil(t, errs["yaml_rate_limited"].Meta)
+
+		registered, ok := LookupError("yaml_rate_limited")
+		assert.True(t, ok)
+		assert.Same(t, errs["yaml_rate_limited"], registered)
+	})
+
+	t.Run("returns empty map for empty document", func(t *testing.T) {
+		errs, err := LoadFromYAML(strings.NewReader(""))
+		assert.NoError(t, err)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("returns error for invalid document", func(t *testing.T) {
+		_, err := LoadFromYAML(strings.NewReader("- not a map"))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects empty definitions without registering", func(t *testing.T) {
+		_, err := LoadFromYAML(strings.NewReader("yaml_defined:\n  status_code: 400\nyaml_empty:\n"))
+		assert.ErrorContains(t, err, `"yaml_empty"`)
+		_, ok := LookupError("yaml_defined")
+		assert.False(t, ok)
+	})
+}